@@ -0,0 +1,137 @@
+package docapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// docCache doc.json serializado, gerado uma única vez e descartado quando a documentação é alterada.
+type docCache struct {
+	json []byte
+	etag string
+	// encoded conteúdo comprimido por encoding (gzip, br...), gerado no primeiro acesso.
+	encoded sync.Map
+}
+
+// lock bloqueia a documentação para alteração, o unlock retornado descarta o doc.json serializado.
+// Deve ser usado em toda alteração da documentação: defer j.lock()()
+func (j *Doc) lock() (unlock func()) {
+	if j == nil {
+		return func() {}
+	}
+
+	j.mu.Lock()
+	return func() {
+		j.cache.Store(nil)
+		j.mu.Unlock()
+	}
+}
+
+// serialized retorna o doc.json serializado, gerando somente quando a documentação foi alterada.
+func (j *Doc) serialized() (*docCache, error) {
+	if cache := j.cache.Load(); cache != nil {
+		return cache, nil
+	}
+
+	// O cache é gravado antes de liberar a leitura, uma alteração posterior sempre o descarta.
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	response, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(response)
+	cache := &docCache{
+		json: response,
+		etag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+
+	j.cache.CompareAndSwap(nil, cache)
+	return cache, nil
+}
+
+// encode retorna o doc.json comprimido, cada encoding tem o próprio ETag (ex.: "hash-gzip").
+func (c *docCache) encode(compression Compression) (body []byte, etag string, err error) {
+	etag = strings.TrimSuffix(c.etag, `"`) + "-" + compression.Encoding + `"`
+
+	if body, ok := c.encoded.Load(compression.Encoding); ok {
+		return body.([]byte), etag, nil
+	}
+
+	body, err = compression.Compress(c.json)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.encoded.Store(compression.Encoding, body)
+	return body, etag, nil
+}
+
+// Compression compressão do doc.json conforme o Accept-Encoding da requisição.
+type Compression struct {
+	// Encoding valor do Content-Encoding, ex.: gzip, br.
+	Encoding string
+	Compress func([]byte) ([]byte, error)
+}
+
+// Gzip compressão gzip da biblioteca padrão.
+var Gzip = Compression{
+	Encoding: "gzip",
+	Compress: func(b []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = w.Write(b); err != nil {
+			return nil, err
+		}
+
+		if err = w.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	},
+}
+
+// acceptEncoding retorna a primeira compressão aceita pela requisição, na ordem configurada.
+func acceptEncoding(header string, compressions []Compression) (Compression, bool) {
+	for _, compression := range compressions {
+		for _, value := range strings.Split(header, ",") {
+			encoding, params, _ := strings.Cut(value, ";")
+			if !strings.EqualFold(strings.TrimSpace(encoding), compression.Encoding) {
+				continue
+			}
+
+			// q=0 indica que o encoding não é aceito.
+			if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok && strings.Trim(q, "0.") == "" {
+				continue
+			}
+
+			return compression, true
+		}
+	}
+
+	return Compression{}, false
+}
+
+// etagMatch compara o If-None-Match com o ETag, conforme RFC 9110 (comparação fraca).
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, value := range strings.Split(ifNoneMatch, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || strings.TrimPrefix(value, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
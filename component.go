@@ -1,14 +1,13 @@
 package docapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"reflect"
 	"strconv"
 	"strings"
-	"time"
-
-	"github.com/google/uuid"
+	"unicode"
 )
 
 type Components struct {
@@ -17,8 +16,32 @@ type Components struct {
 	Security map[string]*SecuritySchemes `json:"securitySchemes,omitempty"`
 	// A Chave é o nome do model/dto
 	Schemas map[string]*Schema `json:"schemas,omitempty"`
+	// InferRequired quando habilitado, os campos sem omitempty e que não são ponteiro são obrigatórios,
+	// exceto quando a tag docapi informar o required.
+	InferRequired bool `json:"-"`
+	// ValidateTag quando habilitado, as regras da tag validate (github.com/go-playground/validator)
+	// são convertidas em restrições do schema. A tag docapi prevalece.
+	ValidateTag bool `json:"-"`
+	// DisableNullablePointer quando habilitado, campos ponteiro não são documentados como nullable,
+	// usado quando o ponteiro indica somente que o campo é opcional.
+	DisableNullablePointer bool `json:"-"`
+	// TypeNull quando habilitado (OpenAPI 3.1), o valor nulo é descrito com type: [tipo, "null"] ao invés de nullable.
+	TypeNull bool `json:"-"`
+	// ExampleDepth quantidade máxima de vezes que uma struct com auto relacionamento é repetida no exemplo.
+	ExampleDepth int `json:"-"`
+	// SchemaNamer estratégia que define o nome do schema, quando nil é usado DefaultSchemaName.
+	SchemaNamer SchemaNamer `json:"-"`
+	// overrides schema e exemplo fixos por tipo, ver AddTypeOverride.
+	overrides map[reflect.Type]typeOverride
+	// nameTypes e typeNames nomes dos schemas reservados por tipo, ver uniqueSchemaName.
+	nameTypes map[string]reflect.Type
+	typeNames map[schemaKey]string
+	errs      []error
 }
 
+// DefaultExampleDepth profundidade padrão do exemplo de struct com auto relacionamento.
+const DefaultExampleDepth = 2
+
 // AddSecurity responsável por adicionar o ss no Components.
 func (c *Components) AddSecurity(ss *SecuritySchemes) {
 	if ss == nil {
@@ -46,151 +69,363 @@ func (c *Components) AddSchemasAndExamples(modelValue reflect.Value, modelType r
 		fn(example)
 	}
 
-	modelName = modelType.Name()
-	if modelName == "" {
-		modelName = example.TypeName
+	if modelType.Name() != "" {
+		modelName = c.schemaName(modelType)
+	} else {
+		modelName = c.uniqueSchemaName(modelType, example.TypeName)
+	}
+
+	var schema *Schema
+
+	// Tipos com schema fixo não são navegados.
+	if fixed, ex, ok := c.fixedSchema(modelType); ok {
+		schema = fixed
+		example.Value = ex
+	} else {
+		example.Value, schema = c.addSchemasAndExamples(modelValue, make(map[reflect.Type]int))
+	}
+
+	if ex, ok := providedExample(modelType); ok {
+		example.Value = ex
 	}
 
-	tokens, examples, properties, required := c.addSchemasAndExamples(modelValue, modelName, dataType, make(map[string]int, 0))
-	example.Tokens = tokens
-	example.Value = examples
+	if dataType == DataTypeArray {
+		example.Value = []any{example.Value}
+	}
 
 	if len(c.Examples) == 0 {
 		c.Examples = Examples{}
 	}
 
 	c.Examples[modelName] = example
-
-	c.addSchema(modelName, &Schema{
-		Type:       DataTypeObject,
-		Properties: properties,
-		Required:   required,
-	})
+	c.addSchema(modelName, schema)
 
 	return
 }
 
-func (c *Components) addSchemasAndExamples(modValue reflect.Value, ownerName string, dataType DataType, navigation map[string]int) (tokens [][]byte, examples, properties any, required []string) {
-	modelName := modValue.Type().Name()
-	if modelName == "" {
-		modelName = ownerName
+// addNamedExamples registra os exemplos nomeados (WithNamedExample) em components/examples,
+// com a chave prefixada pelo modelo ou pela operação no corpo polimórfico (ex.: User.admin),
+// e adiciona a referência no content pelo nome. Retorna false quando não há exemplos nomeados.
+func (c *Components) addNamedExamples(content *Content, prefix string, opts ...OptsExample) bool {
+	example := &Example{}
+	for _, fn := range opts {
+		fn(example)
 	}
 
-	// Essa validação evita loop infinito quando a struct tem auto relacionamento.
-	seq, ok := navigation[modelName]
-	if ok {
-		seq += 1
-		navigation[modelName] = seq
-	} else {
-		navigation[modelName] = 1
+	if len(example.named) == 0 {
+		return false
 	}
 
-	if seq > 2 {
-		return
+	if len(c.Examples) == 0 {
+		c.Examples = Examples{}
 	}
 
-	//examples
-	examplesObject := make(map[string]any, 0)
+	for _, named := range example.named {
+		ex := &Example{}
+		for _, fn := range named.opts {
+			fn(ex)
+		}
+
+		raw, ok := rawExample(named.value)
+		if !ok {
+			continue
+		}
+		ex.Value = raw
+
+		key := exampleKey(prefix, named.name)
+		c.Examples[key] = ex
+		content.addExampleRef(named.name, key)
+	}
+
+	return true
+}
+
+// rawExample serializa o exemplo mantendo a ordem dos campos da struct.
+func rawExample(value any) (json.RawMessage, bool) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		slog.Error("[DocApi]", "erro ao serializar o exemplo", err)
+		return nil, false
+	}
+
+	return raw, true
+}
+
+// exampleKey chave do exemplo nomeado em components/examples, somente com caracteres válidos (^[a-zA-Z0-9\.\-_]+$).
+// O prefixo é separado por ".", que não ocorre no nome dos schemas, evitando conflito com o exemplo do modelo.
+func exampleKey(prefix, name string) string {
+	key := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, name)
 
-	//schemas
-	propValues := make(map[string]any, 0)
+	return prefix + "." + key
+}
 
-	for i := 0; i < modValue.NumField(); i++ {
-		field := modValue.Type().Field(i)
+// addSchemasAndExamples responsável por gerar o schema e o exemplo da struct.
+//
+// Struct embutida com a tag `docapi:"allOf:true"` é referenciada via allOf, ao invés de ter os campos promovidos.
+func (c *Components) addSchemasAndExamples(modValue reflect.Value, navigation map[reflect.Type]int) (examples any, schema *Schema) {
+	// navigation contém a quantidade de vezes que cada struct aparece no caminho percorrido,
+	// usado para limitar a profundidade do exemplo quando a struct tem auto relacionamento.
+	navigation[modValue.Type()]++
+	defer func() { navigation[modValue.Type()]-- }()
 
-		tagjson := field.Tag.Get("json")
-		tagdocapi := field.Tag.Get("docapi")
+	fields := typeFields(modValue.Type())
 
-		if tagjson == "-" {
+	// examples e schemas mantêm a ordem dos campos da struct.
+	examplesObject := NewOrderedMap(len(fields))
+	propValues := NewOrderedMap(len(fields))
+
+	var (
+		required []string
+		allOf    []*Schema
+	)
+
+	// Campos de structs embutidas são promovidos, da mesma forma que o encoding/json.
+	for _, field := range fields {
+		if field.allOf {
+			ref, ex := c.structProperty(reflect.New(indirectType(field.typ)).Elem(), navigation)
+			allOf = append(allOf, ref)
+
+			// No json os campos continuam promovidos.
+			if ex, ok := ex.(*OrderedMap); ok {
+				for _, k := range ex.Keys() {
+					v, _ := ex.Get(k)
+					examplesObject.Set(k, v)
+				}
+			}
 			continue
 		}
 
-		if tagjson == "" {
-			tagjson = field.Name
+		tagjson := field.name
+		tagdocapi := c.parseTags(field)
+
+		property, exvalue := c.propertyOf(field.typ, tagdocapi, navigation)
+
+		// ,string: o encoding/json serializa números e booleanos como string.
+		if field.quoted && property.Type != DataTypeString {
+			property.Type = DataTypeString
+			exvalue = fmt.Sprint(exvalue)
 		}
 
-		fieldType := field.Type
+		tagdocapi.applyField(property)
 
-		if fieldType.Kind() == reflect.Pointer {
-			fieldType = fieldType.Elem()
+		// Ponteiro com omitempty é omitido quando nil, não é serializado como null.
+		if field.omitEmpty && field.typ.Kind() == reflect.Pointer && !tagdocapi.nullable {
+			property.Nullable = false
 		}
+		property = c.refProperty(c.nullableProperty(property))
 
-		// token usado para manter ordenado os valores que são adicionados em map.
-		token := fmt.Sprintf("%d__%s$", i, uuid.New().String())
-		tokens = append(tokens, []byte(token))
+		if c.isRequired(field, tagdocapi) {
+			required = append(required, tagjson)
+		}
 
-		var (
-			newKindIsStruct, ok bool
-			newTypeValue        reflect.Value
-		)
+		propValues.Set(tagjson, property)
+		examplesObject.Set(tagjson, exvalue)
+	}
 
-		//Slice/Array
-		if fieldType, newTypeValue, newKindIsStruct, ok = c.isSlice(fieldType); ok {
-			property := &Property{Type: DataTypeArray, Items: &Items{}}
-			propValues[token+tagjson] = property
-			propertyType, exvalue, enum, isReq := c.parseFieldsAndTag(fieldType.Kind(), tagdocapi)
+	examples = examplesObject
+	schema = &Schema{
+		Type:       DataTypeObject,
+		Properties: propValues,
+		Required:   required,
+	}
 
-			if isReq {
-				required = append(required, tagjson)
-			}
+	if len(allOf) > 0 {
+		schema = &Schema{AllOf: append(allOf, schema)}
+	}
 
-			// Slice/Array de tipo primitivo
-			if !newKindIsStruct {
-				property.Items.Type = propertyType
-				property.Enum = append(property.Enum, enum...)
-				property.ConvertEnumType(propertyType)
+	return
+}
 
-				examplesObject[token+tagjson] = []any{exvalue}
-				continue
-			}
+// propertyOf responsável por gerar a property e o exemplo conforme o tipo do campo.
+// O exemplo informado pelo tipo (ExampleProvider) é usado quando a tag docapi não informar o exemplo.
+func (c *Components) propertyOf(fieldType reflect.Type, tagdocapi tagDocApi, navigation map[reflect.Type]int) (property *Property, example any) {
+	isPointer := fieldType.Kind() == reflect.Pointer
+	fieldType = indirectType(fieldType)
 
-			tk, ex, prop, reqFields := c.addSchemasAndExamples(newTypeValue, tagjson, DataTypeArray, navigation)
-			tokens = append(tokens, tk...)
-			property.Items.Required = reqFields
-			property.Items.Properties = prop
+	property, example = c.typeProperty(fieldType, tagdocapi, navigation)
 
-			examplesObject[token+tagjson] = ex
-			continue
+	if ex, ok := providedExample(fieldType); ok && tagdocapi.example == "" {
+		example = ex
+	}
+
+	property.openAPI31 = c.TypeNull
+
+	// Ponteiro nil é serializado como null.
+	if isPointer && !c.DisableNullablePointer {
+		property.Nullable = true
+	}
+
+	return
+}
+
+// nullableProperty responsável por descrever o valor nulo da property conforme a versão do OpenAPI.
+//
+// 3.0: nullable: true, $ref é envolvido em allOf para que o nullable seja considerado.
+// 3.1: type: [tipo, "null"], $ref é combinado com anyOf: [$ref, {type: "null"}].
+func (c *Components) nullableProperty(property *Property) *Property {
+	if !property.Nullable {
+		return property
+	}
+
+	if property.Ref != "" {
+		wrapper := *property
+		wrapper.Ref = ""
+		ref := &Schema{Ref: property.Ref}
+
+		if c.TypeNull {
+			wrapper.Nullable = false
+			wrapper.AnyOf = []*Schema{ref, {Type: DataTypeNull}}
+			return &wrapper
 		}
 
-		// Struct
-		if newTypeValue, ok = c.isStruct(fieldType); ok {
-			tk, ex, prop, reqFields := c.addSchemasAndExamples(newTypeValue, tagjson, DataTypeObject, navigation)
-			tokens = append(tokens, tk...)
+		wrapper.AllOf = []*Schema{ref}
+		return &wrapper
+	}
 
-			propValues[token+tagjson] = &Property{
-				Required: reqFields,
-				Value:    prop,
-			}
+	property.typeNull = c.TypeNull
+	return property
+}
 
-			examplesObject[token+tagjson] = ex
-			continue
+// refProperty envolve o $ref em allOf quando a property tem outros atributos (description, deprecated...),
+// no OpenAPI 3.0 os atributos ao lado do $ref são ignorados.
+func (c *Components) refProperty(property *Property) *Property {
+	if property.Ref == "" || c.TypeNull {
+		return property
+	}
+
+	if property.Description == "" && !property.Deprecated && !property.ReadOnly && !property.WriteOnly {
+		return property
+	}
+
+	wrapper := *property
+	wrapper.Ref = ""
+	wrapper.AllOf = []*Schema{{Ref: property.Ref}}
+	return &wrapper
+}
+
+// typeProperty responsável por gerar a property e o exemplo conforme o tipo,
+// slice/array e map são resolvidos recursivamente pelo tipo do elemento.
+func (c *Components) typeProperty(fieldType reflect.Type, tagdocapi tagDocApi, navigation map[reflect.Type]int) (property *Property, example any) {
+	// Tipos com schema fixo (AddTypeOverride e SchemaProvider).
+	if fixed, ex, ok := c.fixedSchema(fieldType); ok {
+		tagdocapi.applyValue(fixed)
+
+		if tagdocapi.example != "" {
+			ex = convertType(fixed.Type, tagdocapi.example)
 		}
 
-		propertyType, exvalue, enum, isReq := c.parseFieldsAndTag(fieldType.Kind(), tagdocapi)
+		return fixed, ex
+	}
 
-		if isReq {
-			required = append(required, tagjson)
+	// time.Time, UUID e []byte são serializados como string.
+	if isStringFormat(fieldType) {
+		property, example = c.primitiveProperty(fieldType, tagdocapi)
+		return property, example
+	}
+
+	// Serialização customizada (MarshalText/MarshalJSON) é documentada como string.
+	if isMarshaler(fieldType) {
+		property, example = c.primitiveProperty(stringType, tagdocapi)
+		return property, example
+	}
+
+	//Slice/Array
+	if elemType, ok := c.isSlice(fieldType); ok {
+		items, ex := c.propertyOf(elemType, tagdocapi, navigation)
+
+		example = []any{}
+		if ex != nil {
+			example = []any{ex}
 		}
 
-		property := &Property{
-			Type:   propertyType,
-			Format: fieldType.Name(),
-			Enum:   enum,
+		return &Property{Type: DataTypeArray, Items: c.nullableProperty(items)}, example
+	}
+
+	//Map
+	if keyType, elemType, ok := c.isMap(fieldType); ok {
+		property = &Property{Type: DataTypeObject, AdditionalProperties: &Schema{}}
+
+		// map[string]any aceita qualquer valor.
+		if elemType.Kind() == reflect.Interface {
+			return property, map[string]any{}
+		}
+
+		additional, ex := c.propertyOf(elemType, tagdocapi, navigation)
+		property.AdditionalProperties = c.nullableProperty(additional)
+
+		example = map[string]any{}
+		if ex != nil {
+			_, key, _ := c.parseFieldsAndTag(keyType, tagDocApi{})
+			example = map[string]any{fmt.Sprint(key): ex}
 		}
-		property.ConvertEnumType(propertyType)
-		propValues[token+tagjson] = property
 
-		examplesObject[token+tagjson] = exvalue
+		return property, example
 	}
 
-	examples = examplesObject
-	if dataType == DataTypeArray {
-		examples = []map[string]any{examplesObject}
+	// Struct
+	if newTypeValue, ok := c.isStruct(fieldType); ok {
+		return c.structProperty(newTypeValue, navigation)
 	}
 
-	properties = propValues
-	return
+	property, example = c.primitiveProperty(fieldType, tagdocapi)
+	return property, example
+}
+
+// primitiveProperty responsável por gerar a property dos tipos primitivos, com o formato OpenAPI do tipo.
+func (c *Components) primitiveProperty(fieldType reflect.Type, tagdocapi tagDocApi) (property *Property, example any) {
+	propertyType, exvalue, enum := c.parseFieldsAndTag(fieldType, tagdocapi)
+	format := formatOf(fieldType)
+
+	property = &Property{
+		Type:    propertyType,
+		Format:  format.format,
+		Minimum: format.minimum,
+		Maximum: format.maximum,
+		Enum:    enum,
+	}
+	property.ConvertEnumType(propertyType)
+	tagdocapi.applyValue(property)
+
+	return property, exvalue
+}
+
+// structProperty responsável por gerar a property de um campo do tipo struct.
+// Struct nomeada é registrada em components/schemas e referenciada via $ref, evitando
+// duplicar o mesmo schema em cada modelo que o utiliza. Struct anônima continua inline.
+func (c *Components) structProperty(modValue reflect.Value, navigation map[reflect.Type]int) (property *Property, example any) {
+	var modelName string
+	if modValue.Type().Name() != "" {
+		modelName = c.schemaName(modValue.Type())
+	}
+
+	// Auto relacionamento: ao atingir a profundidade máxima do exemplo apenas referencia o schema,
+	// que é registrado ao final da navegação da struct que está sendo percorrida.
+	if modelName != "" && navigation[modValue.Type()] >= c.exampleDepth() {
+		return &Property{Ref: schemaRef(modelName)}, nil
+	}
+
+	example, schema := c.addSchemasAndExamples(modValue, navigation)
+
+	if modelName == "" {
+		return schema, example
+	}
+
+	c.addSchema(modelName, schema)
+	return &Property{Ref: schemaRef(modelName)}, example
+}
+
+// exampleDepth retorna a quantidade máxima de vezes que uma struct com auto relacionamento
+// é repetida no exemplo.
+func (c *Components) exampleDepth() int {
+	if c.ExampleDepth < 1 {
+		return DefaultExampleDepth
+	}
+	return c.ExampleDepth
 }
 
 func (c *Components) addSchema(modelName string, schema *Schema) {
@@ -201,61 +436,64 @@ func (c *Components) addSchema(modelName string, schema *Schema) {
 	c.Schemas[modelName] = schema
 }
 
-// isSlice responsável por verificar se o type é slice ou array, caso seja, criar reflect.Value do tipo,
-// também indica se o tipo é primitivo ([]int, []string...)
-func (c *Components) isSlice(fieldType reflect.Type) (rt reflect.Type, newTypeValue reflect.Value, newKindIsStruct, ok bool) {
-	rt = fieldType
-	ok = rt.Kind() == reflect.Array || rt.Kind() == reflect.Slice
+// isSlice responsável por verificar se o type é slice ou array, caso seja, retorna o tipo do elemento.
+func (c *Components) isSlice(fieldType reflect.Type) (elemType reflect.Type, ok bool) {
+	ok = fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Slice
 	if ok {
-		rt = rt.Elem()
-		if rt.Kind() == reflect.Pointer {
-			rt = rt.Elem()
-		}
-
-		newTypeValue = reflect.ValueOf(reflect.New(rt).Interface()).Elem()
+		elemType = fieldType.Elem()
+	}
+	return
+}
 
-		newKindIsStruct = newTypeValue.Kind() == reflect.Struct
+// isMap responsável por verificar se o type é map, caso seja, retorna o tipo da chave e do valor.
+func (c *Components) isMap(fieldType reflect.Type) (keyType, elemType reflect.Type, ok bool) {
+	ok = fieldType.Kind() == reflect.Map
+	if ok {
+		keyType = fieldType.Key()
+		elemType = fieldType.Elem()
 	}
 	return
 }
 
 func (c *Components) isStruct(fieldType reflect.Type) (rt reflect.Value, ok bool) {
-	ok = fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{})
+	ok = fieldType.Kind() == reflect.Struct && fieldType != timeType
 	if ok {
 		rt = reflect.ValueOf(reflect.New(fieldType).Interface()).Elem()
 	}
 	return
 }
 
-// parseFieldsAndTag responsável por extrair os dados da tag docapi e o DataType conforme reflect.Kind.
-func (c *Components) parseFieldsAndTag(fieldKind reflect.Kind, tagdocapi string) (pType DataType, exValue any, enum []any, required bool) {
-	var example string
-
-	for _, v := range strings.Split(tagdocapi, ";") {
-		v = strings.TrimSpace(v)
-		if strings.HasPrefix(v, "required:") {
-			required = strings.TrimSpace(strings.TrimPrefix(v, "required:")) == "true"
-			continue
-		}
+// isRequired indica se o campo é obrigatório. A tag docapi prevalece, caso não seja informada e
+// InferRequired esteja habilitado, é obrigatório o campo que sempre é serializado (sem omitempty e não ponteiro).
+func (c *Components) isRequired(f field, tagdocapi tagDocApi) bool {
+	if tagdocapi.required != nil {
+		return *tagdocapi.required
+	}
 
-		if strings.HasPrefix(v, "example:") {
-			example = strings.TrimSpace(strings.TrimPrefix(v, "example:"))
-			continue
-		}
+	return c.InferRequired && !f.omitEmpty && f.typ.Kind() != reflect.Pointer
+}
 
-		if strings.HasPrefix(v, "enum:") {
-			for _, e := range strings.Split(strings.TrimPrefix(v, "enum:"), ",") {
-				enum = append(enum, strings.TrimSpace(e))
-			}
-		}
-	}
+// parseFieldsAndTag responsável por extrair os dados da tag docapi e o DataType conforme reflect.Kind.
+func (c *Components) parseFieldsAndTag(fieldType reflect.Type, tagdocapi tagDocApi) (pType DataType, exValue any, enum []any) {
+	example := tagdocapi.example
+	enum = append(enum, tagdocapi.enum...)
 
 	var (
 		defaultValue any
 		err          error
 	)
 
-	switch fieldKind.String() {
+	kind := fieldType.Kind().String()
+	switch {
+	case fieldType == timeType:
+		kind = "Time"
+	case isUUID(fieldType):
+		kind = "UUID"
+	case isBytes(fieldType):
+		kind = "bytes"
+	}
+
+	switch kind {
 	case "int", "int8", "int16", "int32", "int64",
 		"uint", "uint8", "uint16", "uint32", "uint64":
 		pType = DataTypeInteger
@@ -274,7 +512,17 @@ func (c *Components) parseFieldsAndTag(fieldKind reflect.Kind, tagdocapi string)
 
 	case "Time":
 		pType = DataTypeString
-		defaultValue = "datetime"
+		defaultValue = "2024-01-01T00:00:00Z"
+		exValue = example
+
+	case "UUID":
+		pType = DataTypeString
+		defaultValue = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		exValue = example
+
+	case "bytes":
+		pType = DataTypeString
+		defaultValue = "c3RyaW5n"
 		exValue = example
 
 	default:
@@ -0,0 +1,636 @@
+package docapi
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	Street string `json:"street"`
+}
+
+type testUser struct {
+	ID        int64         `json:"id"`
+	Address   testAddress   `json:"address"`
+	Addresses []testAddress `json:"addresses"`
+}
+
+func addSchemas(c *Components, model any) string {
+	modelType := reflect.TypeOf(model)
+	return c.AddSchemasAndExamples(reflect.New(modelType).Elem(), modelType, DataTypeObject)
+}
+
+func findProperty(t *testing.T, schema *Schema, name string) *Property {
+	t.Helper()
+
+	properties, ok := schema.Properties.(*OrderedMap)
+	if !ok {
+		t.Fatalf("expected properties map but we got %T", schema.Properties)
+	}
+
+	property, ok := properties.Get(name)
+	if !ok {
+		t.Fatalf("property %s not found", name)
+	}
+
+	return property.(*Property)
+}
+
+func TestAddSchemasAndExamplesNestedRef(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testUser{})
+
+	if _, ok := c.Schemas["testAddress"]; !ok {
+		t.Fatal("expected testAddress registered in components/schemas")
+	}
+
+	user := c.Schemas["testUser"]
+	if ref := findProperty(t, user, "address").Ref; ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected $ref #/components/schemas/testAddress but we got %s", ref)
+	}
+
+	addresses := findProperty(t, user, "addresses")
+	if addresses.Type != DataTypeArray || addresses.Items.Ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected array with items $ref testAddress but we got %+v", addresses)
+	}
+}
+
+type testNode struct {
+	ID       int64      `json:"id"`
+	Children []testNode `json:"children"`
+	Parent   *testNode  `json:"parent"`
+}
+
+func TestAddSchemasAndExamplesRecursive(t *testing.T) {
+	c := &Components{ExampleDepth: 1}
+	addSchemas(c, testNode{})
+
+	node := c.Schemas["testNode"]
+	if parent := findProperty(t, node, "parent"); len(parent.AllOf) != 1 || parent.AllOf[0].Ref != "#/components/schemas/testNode" {
+		t.Errorf("expected allOf $ref #/components/schemas/testNode but we got %+v", parent)
+	}
+
+	if ref := findProperty(t, node, "children").Items.Ref; ref != "#/components/schemas/testNode" {
+		t.Errorf("expected items $ref #/components/schemas/testNode but we got %s", ref)
+	}
+
+	example := c.Examples["testNode"].Value.(*OrderedMap)
+	for _, k := range example.Keys() {
+		v, _ := example.Get(k)
+		switch k {
+		case "children":
+			if len(v.([]any)) != 0 {
+				t.Errorf("expected empty children example but we got %v", v)
+			}
+		case "parent":
+			if v != nil {
+				t.Errorf("expected nil parent example but we got %v", v)
+			}
+		}
+	}
+}
+
+type testLabels struct {
+	Labels    map[string]string        `json:"labels"`
+	Addresses map[string][]testAddress `json:"addresses"`
+	Metadata  map[string]any           `json:"metadata"`
+}
+
+func TestAddSchemasAndExamplesMap(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testLabels{})
+
+	schema := c.Schemas["testLabels"]
+
+	labels := findProperty(t, schema, "labels")
+	if labels.Type != DataTypeObject || labels.AdditionalProperties.Type != DataTypeString {
+		t.Errorf("expected object with additionalProperties string but we got %+v", labels)
+	}
+
+	addresses := findProperty(t, schema, "addresses").AdditionalProperties
+	if addresses.Type != DataTypeArray || addresses.Items.Ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected additionalProperties array of testAddress but we got %+v", addresses)
+	}
+
+	metadata := findProperty(t, schema, "metadata")
+	if metadata.AdditionalProperties == nil || metadata.AdditionalProperties.Type != schemaNone {
+		t.Errorf("expected additionalProperties without type but we got %+v", metadata.AdditionalProperties)
+	}
+}
+
+type testAudit struct {
+	CreatedBy string `json:"createdBy"`
+	Name      string `json:"name"`
+}
+
+type testBase struct {
+	ID int64 `json:"id"`
+	*testAudit
+}
+
+type testCustomer struct {
+	testBase
+	Name  string      `json:"name"`
+	Audit testAudit   `json:"audit"`
+	Extra testAddress `json:"-"`
+}
+
+type testCustomerTagged struct {
+	testAddress `json:"address"`
+}
+
+func TestAddSchemasAndExamplesEmbedded(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testCustomer{})
+	addSchemas(c, testCustomerTagged{})
+
+	var names []string
+	for _, f := range typeFields(reflect.TypeOf(testCustomer{})) {
+		names = append(names, f.name)
+	}
+
+	if expected := []string{"id", "createdBy", "name", "audit"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected fields %v but we got %v", expected, names)
+	}
+
+	if _, ok := c.Schemas["testBase"]; ok {
+		t.Error("expected embedded testBase flattened, not registered in components/schemas")
+	}
+
+	if ref := findProperty(t, c.Schemas["testCustomerTagged"], "address").Ref; ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected tagged embedded field as $ref testAddress but we got %s", ref)
+	}
+}
+
+type testJsonTag struct {
+	ID       int64   `json:"id,string"`
+	Name     string  `json:"name,omitempty"`
+	Email    string  `json:"email"`
+	Phone    *string `json:"phone"`
+	Nickname string  `json:"nickname,omitempty" docapi:"required:true"`
+	internal string
+}
+
+func TestAddSchemasAndExamplesJsonTag(t *testing.T) {
+	c := &Components{InferRequired: true}
+	addSchemas(c, testJsonTag{})
+
+	schema := c.Schemas["testJsonTag"]
+
+	var names []string
+	for _, f := range typeFields(reflect.TypeOf(testJsonTag{})) {
+		names = append(names, f.name)
+	}
+
+	if expected := []string{"id", "name", "email", "phone", "nickname"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected fields %v but we got %v", expected, names)
+	}
+
+	if id := findProperty(t, schema, "id"); id.Type != DataTypeString || id.Format != "int64" {
+		t.Errorf("expected id string with format int64 but we got %+v", id)
+	}
+
+	if expected := []string{"id", "email", "nickname"}; !reflect.DeepEqual(schema.Required, expected) {
+		t.Errorf("expected required %v but we got %v", expected, schema.Required)
+	}
+}
+
+type testFormats struct {
+	Int8      int8          `json:"int8"`
+	Uint32    uint32        `json:"uint32"`
+	Float32   float32       `json:"float32"`
+	Float64   float64       `json:"float64"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Timeout   time.Duration `json:"timeout"`
+	Data      []byte        `json:"data"`
+	Uint64    uint64        `json:"uint64"`
+	UUID      UUID          `json:"uuid"`
+}
+
+// UUID representa um UUID como [16]byte, da mesma forma que github.com/google/uuid.
+type UUID [16]byte
+
+func TestAddSchemasAndExamplesFormats(t *testing.T) {
+	c := &Components{}
+	c.AddTypeOverride(reflect.TypeOf(UUID{}), &Schema{Type: DataTypeString, Format: "uuid"}, nil)
+	addSchemas(c, testFormats{})
+
+	schema := c.Schemas["testFormats"]
+
+	expected := map[string]struct {
+		dataType DataType
+		format   string
+	}{
+		"int8":      {DataTypeInteger, "int32"},
+		"uint32":    {DataTypeInteger, "int64"},
+		"float32":   {DataTypeNumber, "float"},
+		"float64":   {DataTypeNumber, "double"},
+		"createdAt": {DataTypeString, "date-time"},
+		"timeout":   {DataTypeInteger, "int64"},
+		"data":      {DataTypeString, "byte"},
+		"uint64":    {DataTypeInteger, ""},
+		"uuid":      {DataTypeString, "uuid"},
+	}
+
+	for name, e := range expected {
+		p := findProperty(t, schema, name)
+		if p.Type != e.dataType || p.Format != e.format {
+			t.Errorf("%s: expected %s/%s but we got %s/%s", name, e.dataType, e.format, p.Type, p.Format)
+		}
+	}
+
+	if p := findProperty(t, schema, "int8"); *p.Minimum != -128 || *p.Maximum != 127 {
+		t.Errorf("expected int8 bounds -128..127 but we got %v..%v", *p.Minimum, *p.Maximum)
+	}
+
+	if p := findProperty(t, schema, "uint64"); p.Minimum == nil || *p.Minimum != 0 {
+		t.Errorf("expected uint64 minimum 0 but we got %+v", p)
+	}
+
+	// Somente o nome do tipo não define o formato uuid.
+	c = &Components{}
+	addSchemas(c, testFormats{})
+	if p := findProperty(t, c.Schemas["testFormats"], "uuid"); p.Format == "uuid" {
+		t.Errorf("expected UUID without override not documented as uuid but we got %+v", p)
+	}
+}
+
+type testConstraints struct {
+	Name  string   `json:"name" docapi:"minLength:3;maxLength:50;pattern:^[a-z]+$;description:User name;default:john"`
+	Age   int      `json:"age" docapi:"minimum:18;exclusiveMinimum:true;multipleOf:1"`
+	Tags  []string `json:"tags" docapi:"minItems:1;maxItems:5;uniqueItems:true;format:slug"`
+	Token string   `json:"token" docapi:"writeOnly:true;deprecated:true;nullable:true"`
+}
+
+func TestAddSchemasAndExamplesConstraints(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testConstraints{})
+
+	schema := c.Schemas["testConstraints"]
+
+	name := findProperty(t, schema, "name")
+	if *name.MinLength != 3 || *name.MaxLength != 50 || name.Pattern != "^[a-z]+$" || name.Description != "User name" || name.Default != "john" {
+		t.Errorf("unexpected name constraints %+v", name)
+	}
+
+	age := findProperty(t, schema, "age")
+	if *age.Minimum != 18 || !age.ExclusiveMinimum || *age.MultipleOf != 1 {
+		t.Errorf("unexpected age constraints %+v", age)
+	}
+
+	tags := findProperty(t, schema, "tags")
+	if *tags.MinItems != 1 || *tags.MaxItems != 5 || !tags.UniqueItems || tags.Items.Format != "slug" {
+		t.Errorf("unexpected tags constraints %+v", tags)
+	}
+
+	token := findProperty(t, schema, "token")
+	if !token.WriteOnly || !token.Deprecated || !token.Nullable {
+		t.Errorf("unexpected token attributes %+v", token)
+	}
+}
+
+func TestAddSchemasAndExamplesExclusiveBounds(t *testing.T) {
+	for _, tt := range []struct {
+		typeNull bool
+		expected string
+	}{
+		{false, `{"type":"integer","format":"int64","minimum":18,"exclusiveMinimum":true,"multipleOf":1}`},
+		{true, `{"format":"int64","multipleOf":1,"type":"integer","exclusiveMinimum":18}`},
+	} {
+		c := &Components{TypeNull: tt.typeNull}
+		addSchemas(c, testConstraints{})
+
+		age, err := json.Marshal(findProperty(t, c.Schemas["testConstraints"], "age"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(age) != tt.expected {
+			t.Errorf("typeNull %v: expected %s but we got %s", tt.typeNull, tt.expected, age)
+		}
+	}
+}
+
+type testValidate struct {
+	Name   string            `json:"name" validate:"required,min=3,max=50"`
+	Email  string            `json:"email" validate:"omitempty,email"`
+	Age    int               `json:"age" validate:"gte=18,lt=130"`
+	Role   string            `json:"role" validate:"oneof=admin guest" docapi:"enum:admin"`
+	Emails []string          `json:"emails" validate:"min=1,dive,email,max=100"`
+	Labels map[string]string `json:"labels" validate:"min=1,max=5"`
+}
+
+func TestAddSchemasAndExamplesValidateTag(t *testing.T) {
+	c := &Components{ValidateTag: true}
+	addSchemas(c, testValidate{})
+
+	schema := c.Schemas["testValidate"]
+
+	if expected := []string{"name"}; !reflect.DeepEqual(schema.Required, expected) {
+		t.Errorf("expected required %v but we got %v", expected, schema.Required)
+	}
+
+	if name := findProperty(t, schema, "name"); *name.MinLength != 3 || *name.MaxLength != 50 {
+		t.Errorf("unexpected name constraints %+v", name)
+	}
+
+	if email := findProperty(t, schema, "email"); email.Format != "email" {
+		t.Errorf("expected email format but we got %s", email.Format)
+	}
+
+	if age := findProperty(t, schema, "age"); *age.Minimum != 18 || *age.Maximum != 130 || !age.ExclusiveMaximum {
+		t.Errorf("unexpected age constraints %+v", age)
+	}
+
+	if role := findProperty(t, schema, "role"); !reflect.DeepEqual(role.Enum, []any{"admin"}) {
+		t.Errorf("expected docapi enum to prevail but we got %v", role.Enum)
+	}
+
+	emails := findProperty(t, schema, "emails")
+	if *emails.MinItems != 1 || emails.Items.Format != "email" || *emails.Items.MaxLength != 100 {
+		t.Errorf("unexpected emails constraints %+v items %+v", emails, emails.Items)
+	}
+
+	labels := findProperty(t, schema, "labels")
+	if labels.MinProperties == nil || *labels.MinProperties != 1 || labels.MaxProperties == nil || *labels.MaxProperties != 5 || labels.MinItems != nil {
+		t.Errorf("unexpected labels constraints %+v", labels)
+	}
+}
+
+type testMoney struct {
+	cents int64
+}
+
+func (testMoney) DocApiSchema() *Schema {
+	return &Schema{Type: DataTypeString, Format: "decimal", Pattern: `^\d+\.\d{2}$`}
+}
+
+func (testMoney) DocApiExample() any {
+	return "10.50"
+}
+
+type testStatus int
+
+func (s testStatus) MarshalText() ([]byte, error) {
+	return []byte("active"), nil
+}
+
+type testOrder struct {
+	Total  testMoney  `json:"total" docapi:"description:Order total"`
+	Status testStatus `json:"status" docapi:"enum:active,inactive"`
+}
+
+func TestAddSchemasAndExamplesProvider(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testOrder{})
+
+	schema := c.Schemas["testOrder"]
+
+	total := findProperty(t, schema, "total")
+	if total.Type != DataTypeString || total.Format != "decimal" || total.Pattern == "" || total.Description != "Order total" {
+		t.Errorf("expected schema from DocApiSchema but we got %+v", total)
+	}
+
+	status := findProperty(t, schema, "status")
+	if status.Type != DataTypeString || !reflect.DeepEqual(status.Enum, []any{"active", "inactive"}) {
+		t.Errorf("expected TextMarshaler documented as string enum but we got %+v", status)
+	}
+
+	if v, _ := c.Examples["testOrder"].Value.(*OrderedMap).Get("total"); v != "10.50" {
+		t.Errorf("expected example from DocApiExample but we got %v", v)
+	}
+}
+
+type testDecimal struct {
+	value string
+	exp   int32
+}
+
+type testOverride struct {
+	Price    testDecimal     `json:"price"`
+	Nickname sql.NullString  `json:"nickname"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+func TestAddSchemasAndExamplesTypeOverride(t *testing.T) {
+	c := &Components{}
+	c.AddTypeOverride(reflect.TypeOf(testDecimal{}), &Schema{Type: DataTypeString, Format: "decimal"}, "10.50")
+	addSchemas(c, testOverride{})
+
+	schema := c.Schemas["testOverride"]
+
+	if price := findProperty(t, schema, "price"); price.Type != DataTypeString || price.Format != "decimal" {
+		t.Errorf("expected registered schema string/decimal but we got %+v", price)
+	}
+
+	if _, ok := c.Schemas["testDecimal"]; ok {
+		t.Error("expected testDecimal not registered in components/schemas")
+	}
+
+	// sql.NullString é serializado como objeto pelo encoding/json.
+	if nickname := findProperty(t, schema, "nickname"); nickname.Ref != "#/components/schemas/NullString" {
+		t.Errorf("expected sql.NullString as $ref NullString but we got %+v", nickname)
+	}
+
+	findProperty(t, c.Schemas["NullString"], "String")
+	findProperty(t, c.Schemas["NullString"], "Valid")
+
+	if payload := findProperty(t, schema, "payload"); payload.Type != schemaNone {
+		t.Errorf("expected json.RawMessage without type but we got %+v", payload)
+	}
+}
+
+type testEntity struct {
+	ID int64 `json:"id"`
+}
+
+type testProduct struct {
+	testEntity `docapi:"allOf:true"`
+	Name       string `json:"name"`
+}
+
+func TestAddSchemasAndExamplesAllOf(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testProduct{})
+
+	schema := c.Schemas["testProduct"]
+	if len(schema.AllOf) != 2 || schema.AllOf[0].Ref != "#/components/schemas/testEntity" {
+		t.Fatalf("expected allOf [$ref testEntity, object] but we got %+v", schema.AllOf)
+	}
+
+	findProperty(t, schema.AllOf[1], "name")
+
+	names := c.Examples["testProduct"].Value.(*OrderedMap).Keys()
+
+	if len(names) != 2 {
+		t.Errorf("expected example with promoted id and name but we got %v", names)
+	}
+}
+
+type testNullable struct {
+	Name     *string      `json:"name"`
+	Nickname *string      `json:"nickname,omitempty"`
+	Address  *testAddress `json:"address"`
+	Tags     []*string    `json:"tags"`
+}
+
+func TestAddSchemasAndExamplesNullable(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testNullable{})
+
+	schema := c.Schemas["testNullable"]
+
+	if name := findProperty(t, schema, "name"); !name.Nullable {
+		t.Errorf("expected pointer nullable but we got %+v", name)
+	}
+
+	if nickname := findProperty(t, schema, "nickname"); nickname.Nullable {
+		t.Errorf("expected pointer with omitempty not nullable but we got %+v", nickname)
+	}
+
+	if tags := findProperty(t, schema, "tags"); !tags.Items.Nullable {
+		t.Errorf("expected pointer items nullable but we got %+v", tags.Items)
+	}
+
+	c = &Components{TypeNull: true}
+	addSchemas(c, testNullable{})
+
+	schema = c.Schemas["testNullable"]
+
+	name, err := json.Marshal(findProperty(t, schema, "name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"type":["string","null"]}`; string(name) != expected {
+		t.Errorf("expected %s but we got %s", expected, name)
+	}
+
+	address := findProperty(t, schema, "address")
+	if len(address.AnyOf) != 2 || address.AnyOf[1].Type != DataTypeNull {
+		t.Errorf("expected anyOf [$ref, null] but we got %+v", address)
+	}
+
+	c = &Components{DisableNullablePointer: true}
+	addSchemas(c, testNullable{})
+
+	if name := findProperty(t, c.Schemas["testNullable"], "name"); name.Nullable {
+		t.Errorf("expected pointer not nullable but we got %+v", name)
+	}
+}
+
+type testPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+func TestSchemaName(t *testing.T) {
+	pageType := reflect.TypeOf(testPage[testAddress]{})
+
+	if name := DefaultSchemaName(pageType); name != "testPageTestAddress" {
+		t.Errorf("expected testPageTestAddress but we got %s", name)
+	}
+
+	if name := PackageSchemaName(pageType); name != "DocapiTestPageDocapiTestAddress" {
+		t.Errorf("expected DocapiTestPageDocapiTestAddress but we got %s", name)
+	}
+
+	if name := DefaultSchemaName(reflect.TypeOf(testPage[map[string]*testAddress]{})); name != "testPageMapStringTestAddress" {
+		t.Errorf("expected testPageMapStringTestAddress but we got %s", name)
+	}
+
+	c := &Components{SchemaNamer: PackageSchemaName}
+	if name := addSchemas(c, testUser{}); name != "DocapiTestUser" {
+		t.Errorf("expected DocapiTestUser but we got %s", name)
+	}
+
+	if _, ok := c.Schemas["DocapiTestAddress"]; !ok {
+		t.Error("expected nested schema named by the SchemaNamer")
+	}
+}
+
+type testEndereço struct {
+	Rua string `json:"rua"`
+}
+
+func TestSchemaNameNonASCII(t *testing.T) {
+	if name := DefaultSchemaName(reflect.TypeOf(testEndereço{})); name != "testEndere_o" {
+		t.Errorf("expected testEndere_o but we got %s", name)
+	}
+
+	if name := DefaultSchemaName(reflect.TypeOf(testPage[testEndereço]{})); name != "testPageTestEndere_o" {
+		t.Errorf("expected testPageTestEndere_o but we got %s", name)
+	}
+
+	c := &Components{}
+	if name := addSchemas(c, testEndereço{}); name != "testEndere_o" {
+		t.Errorf("expected component key testEndere_o but we got %s", name)
+	}
+}
+
+func TestSchemaNameCollision(t *testing.T) {
+	c := &Components{SchemaNamer: func(reflect.Type) string { return "Response" }}
+	addSchemas(c, testUser{})
+
+	if _, ok := c.Schemas["Response"]; !ok {
+		t.Fatal("expected testUser registered as Response")
+	}
+
+	if _, ok := c.Schemas["DocapiTestAddress"]; !ok {
+		t.Error("expected testAddress resolved with the package name")
+	}
+
+	c = &Components{}
+	first := struct{ ID int }{}
+	second := struct{ Name string }{}
+
+	for _, model := range []any{first, second, second} {
+		modelType := reflect.TypeOf(model)
+		c.AddSchemasAndExamples(reflect.New(modelType).Elem(), modelType, DataTypeObject, WithTypeName("Response"))
+	}
+
+	var collision *SchemaCollisionError
+	if !errors.As(c.Err(), &collision) || collision.Name != "Response" {
+		t.Fatalf("expected SchemaCollisionError but we got %v", c.Err())
+	}
+
+	if _, ok := c.Schemas["Response2"]; !ok || len(c.Schemas) != 2 {
+		t.Errorf("expected second type registered once as Response2 but we got %v", c.Schemas)
+	}
+}
+
+type testLegacy struct {
+	Address testAddress `json:"address" docapi:"deprecated:true;description:use addresses"`
+	Other   testAddress `json:"other"`
+}
+
+func TestAddSchemasAndExamplesDeprecatedRef(t *testing.T) {
+	c := &Components{}
+	addSchemas(c, testLegacy{})
+
+	address := findProperty(t, c.Schemas["testLegacy"], "address")
+	if !address.Deprecated || address.Ref != "" || len(address.AllOf) != 1 || address.AllOf[0].Ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected deprecated allOf [$ref testAddress] but we got %+v", address)
+	}
+
+	if other := findProperty(t, c.Schemas["testLegacy"], "other"); other.Ref != "#/components/schemas/testAddress" {
+		t.Errorf("expected plain $ref but we got %+v", other)
+	}
+}
+
+func TestRefCompatibility(t *testing.T) {
+	schema := &Schema{OneOf: []*Ref{{Ref: schemaRef("testUser")}}}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"oneOf":[{"$ref":"#/components/schemas/testUser"}]}`; string(b) != expected {
+		t.Errorf("expected %s but we got %s", expected, b)
+	}
+}
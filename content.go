@@ -8,16 +8,22 @@ type Content struct {
 }
 
 func (c *Content) AddExamplesRef(modelName string) {
-	ref := "#/components/examples/" + modelName
+	c.addExampleRef(modelName, modelName)
+}
+
+// addExampleRef adiciona em content/examples a referência para o exemplo de components/examples.
+func (c *Content) addExampleRef(name, componentName string) {
+	c.addExample(name, &Example{Ref: "#/components/examples/" + componentName})
+}
+
+// addExample adiciona o exemplo em content/examples.
+func (c *Content) addExample(name string, example *Example) {
 	if len(c.Examples) == 0 {
-		c.Examples = Examples{
-			modelName: &Example{
-				Ref: ref},
-		}
+		c.Examples = Examples{name: example}
 		return
 	}
 
-	c.Examples[modelName] = &Example{Ref: ref}
+	c.Examples[name] = example
 }
 
 func NewContent() *Content {
@@ -0,0 +1,68 @@
+package docapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// deprecationState datas informadas nos headers do endpoint deprecated.
+type deprecationState struct {
+	deprecation time.Time
+	sunset      time.Time
+}
+
+// deprecate marca o endpoint como deprecated para o controller, deve ser chamado com a documentação bloqueada.
+func (p *PathsStructure) deprecate(fn func(*deprecationState)) {
+	state := deprecationState{}
+	if current := p.deprecation.Load(); current != nil {
+		state = *current
+	}
+
+	fn(&state)
+	p.deprecation.Store(&state)
+}
+
+// handler retorna o controller do endpoint, quando habilitado via StartDocApi.DeprecationHeaders adiciona os headers
+// Deprecation e Sunset se o endpoint estiver deprecated. A marcação é lida em cada requisição, ou seja, Deprecated
+// e Sunset chamados após o HandleFunc/MethodFunc também são considerados.
+func (p *PathsStructure) handler() http.HandlerFunc {
+	if p.Doc == nil || p.H == nil || !p.Doc.deprecationHeaders.Load() {
+		return p.H
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if state := p.deprecation.Load(); state != nil {
+			setDeprecationHeaders(w.Header(), state.deprecation, state.sunset)
+		}
+
+		p.H(w, r)
+	}
+}
+
+// DeprecationMiddleware adiciona na resposta os headers Deprecation (RFC 9745) e Sunset (RFC 8594),
+// o Sunset é informado somente quando a data não é zero.
+func DeprecationMiddleware(deprecation, sunset time.Time) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setDeprecationHeaders(w.Header(), deprecation, sunset)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setDeprecationHeaders informa a data da descontinuação no formato da RFC 9745 (@<unix>).
+// Sem a data, o RFC não tem um valor equivalente e é usado o formato "true" do draft anterior
+// (draft-ietf-httpapi-deprecation-header-02), ainda reconhecido por parte dos clientes.
+func setDeprecationHeaders(h http.Header, deprecation, sunset time.Time) {
+	if deprecation.IsZero() {
+		h.Set("Deprecation", "true")
+	} else {
+		h.Set("Deprecation", "@"+strconv.FormatInt(deprecation.Unix(), 10))
+	}
+
+	if !sunset.IsZero() {
+		h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
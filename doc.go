@@ -5,6 +5,8 @@ package docapi
 
 import (
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type Doc struct {
@@ -14,8 +16,23 @@ type Doc struct {
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
 	Info         *Info         `json:"info,omitempty"`
 	// a chave é o path do endpoint
-	Paths      map[string]Path `json:"paths,omitempty"`
-	Components *Components     `json:"components,omitempty"`
+	Paths      map[string]Path  `json:"paths,omitempty"`
+	Components *Components      `json:"components,omitempty"`
+	Tags       []*TagDefinition `json:"tags,omitempty"`
+	// TagGroups agrupamento das tags no Redoc, ver WithTagGroup.
+	TagGroups []TagGroup `json:"x-tagGroups,omitempty"`
+	// mu protege a documentação das alterações concorrentes ao doc.json sendo servido, ver lock.
+	mu sync.RWMutex
+	// cache doc.json serializado, ver serialized.
+	cache atomic.Pointer[docCache]
+	// errs erros encontrados ao registrar os endpoints, ver Validate.
+	errs []error
+	// operationIDs endpoint de cada operationId, usado para manter o operationId único.
+	operationIDs map[string]*PathsStructure
+	// deprecationHeaders adiciona os headers Deprecation e Sunset nos endpoints deprecated, ver StartDocApi.DeprecationHeaders.
+	deprecationHeaders atomic.Bool
+	// validateOnHandle valida o endpoint no HandleFunc/MethodFunc, ver StartDocApi.ValidateOnHandle.
+	validateOnHandle bool
 }
 
 type Servers struct {
@@ -41,6 +58,7 @@ type License struct {
 }
 
 func (j *Doc) AddServer(url ...string) {
+	defer j.lock()()
 	for _, v := range url {
 		if !j.serverIsPresent(v) {
 			j.Servers = append(j.Servers, Servers{URL: v})
@@ -58,19 +76,41 @@ func (j *Doc) serverIsPresent(url string) (ok bool) {
 }
 
 func (j *Doc) AddPath(method, pattern string, pathStructure *PathsStructure) {
+	defer j.lock()()
+
 	method = strings.ToLower(method)
 	// A raiz do path é a url e dentro contém os métodos get, post, put...
 	// Se localizar a url, então adiciona o método.
 	if len(j.Paths) == 0 {
-		j.Paths = map[string]Path{pattern: {method: pathStructure}}
-		return
+		j.Paths = make(map[string]Path, 1)
 	}
 
-	if paths, ok := j.Paths[pattern]; ok {
-		paths[method] = pathStructure
+	paths, ok := j.Paths[pattern]
+	if !ok {
+		paths = Path{}
 		j.Paths[pattern] = paths
+	}
+
+	// O registro anterior é substituído, a duplicidade é reportada no Validate.
+	if previous, ok := paths[method]; ok {
+		j.errs = append(j.errs, &ValidationError{Method: method, Path: pattern, Message: "duplicate registration"})
+		delete(j.operationIDs, previous.OpId)
+	}
+
+	paths[method] = pathStructure
 
-	} else {
-		j.Paths[pattern] = Path{method: pathStructure}
+	// O operationId padrão já usado por outro endpoint (ex.: o mesmo controller em várias rotas) é substituído pelo método e path.
+	id := pathStructure.OpId
+	if owner := j.operationIDs[id]; owner != nil && owner != pathStructure {
+		id = defaultOperationID("", method, pattern)
 	}
+
+	pathStructure.OpId = j.reserveOperationID(id, pathStructure)
+}
+
+// addSecurity adiciona o security scheme em components.
+func (j *Doc) addSecurity(ss *SecuritySchemes) {
+	defer j.lock()()
+
+	j.Components.AddSecurity(ss)
 }
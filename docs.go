@@ -2,7 +2,6 @@ package docapi
 
 import (
 	"bytes"
-	"encoding/json"
 	"log/slog"
 	"strings"
 	"sync"
@@ -16,13 +15,16 @@ var (
 func GetDocs() *Docs {
 	once.Do(
 		func() {
-			doc = &Docs{make(map[string]*Doc)}
+			doc = &Docs{Docs: make(map[string]*Doc)}
 		})
 	return doc
 }
 
+// Docs registro das documentações, seguro para uso concorrente via NewDoc e FindDocJSONByPath.
+// O acesso direto ao mapa Docs não é sincronizado.
 type Docs struct {
 	Docs map[string]*Doc
+	mu   sync.RWMutex
 }
 
 // NewDoc responsável por criar a configuração padrão para o doc.json.
@@ -38,6 +40,10 @@ func (d *Docs) NewDoc(pathDocJson string) *Doc {
 		},
 		Components: &Components{},
 	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	d.Docs[pathDocJson] = doc
 	return doc
 }
@@ -47,7 +53,10 @@ func (d *Docs) NewDoc(pathDocJson string) *Doc {
 // path: Ex.: URL = http://localhost:8080/swagger: path = /swagger/
 func (d *Docs) FindDocJSONByPath(path string) (doc *Doc, ok bool) {
 	path = strings.TrimSuffix(path, "/")
+
+	d.mu.RLock()
 	doc, ok = d.Docs[path+"/doc.json"]
+	d.mu.RUnlock()
 	if ok {
 		return
 	}
@@ -56,28 +65,18 @@ func (d *Docs) FindDocJSONByPath(path string) (doc *Doc, ok bool) {
 	return
 }
 
+// GetJSON retorna o doc.json serializado, gerado uma única vez até a próxima alteração da documentação.
 func (d *Docs) GetJSON(rURLPath string) (response []byte) {
 	doc, ok := d.FindDocJSONByPath(strings.TrimSuffix(rURLPath, "/doc.json"))
 	if !ok {
 		return
 	}
 
-	var err error
-	response, err = json.Marshal(doc)
+	cache, err := doc.serialized()
 	if err != nil {
 		slog.Error("error when creating Swagger doc.json file.", "error", err.Error())
 		return
 	}
 
-	if doc.Components == nil {
-		return
-	}
-
-	for _, v := range doc.Components.Examples {
-		for _, token := range v.Tokens {
-			response = bytes.ReplaceAll(response, token, []byte(""))
-		}
-
-	}
-	return
+	return bytes.Clone(cache.json)
 }
@@ -0,0 +1,67 @@
+package docapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentRegisterAndServe registra endpoints enquanto o doc.json é servido, deve ser executado com -race.
+func TestConcurrentRegisterAndServe(t *testing.T) {
+	api := NewDocApi("localhost:8080/race-test")
+	_, handler := api.HandlerFunc(WithCompression(Gzip))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			router := api.NewRouterSecurityBearer()
+			for j := range 20 {
+				router.Get(fmt.Sprintf("/users/%d/%d", i, j), testHandler).
+					Summary("user").
+					ParamQuery("name", DataTypeString).
+					RequestBodyJson(benchUser{}).
+					ResponseBodyJson(http.StatusOK, "ok", []benchOrder{{ID: int64(j)}})
+			}
+			api.Server(fmt.Sprintf("http://localhost:%d", 8080+i))
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 20 {
+				r := httptest.NewRequest(http.MethodGet, "/race-test/doc.json", nil)
+				r.Header.Set("Accept-Encoding", "gzip")
+				handler(httptest.NewRecorder(), r)
+
+				GetDocs().GetJSON("/race-test/doc.json")
+				_ = api.Err()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	doc, _ := GetDocs().FindDocJSONByPath("/race-test")
+	if len(doc.Paths) != 160 {
+		t.Errorf("expected 160 paths but we got %d", len(doc.Paths))
+	}
+}
+
+func TestConcurrentNewDoc(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewDocApi(fmt.Sprintf("localhost:8080/race-doc-%d", i))
+			GetDocs().GetJSON(fmt.Sprintf("/race-doc-%d/doc.json", i))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,67 @@
+package docapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type benchAddress struct {
+	Street  string `json:"street" docapi:"example:Rua A"`
+	City    string `json:"city" docapi:"example:São Paulo"`
+	Country string `json:"country" docapi:"example:BR"`
+}
+
+type benchOrder struct {
+	ID        int64          `json:"id" docapi:"example:1"`
+	Items     []string       `json:"items"`
+	Total     float64        `json:"total" docapi:"example:10.5"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Address   benchAddress   `json:"address"`
+	Labels    map[string]int `json:"labels"`
+}
+
+type benchUser struct {
+	ID      int64         `json:"id" docapi:"example:1"`
+	Name    string        `json:"name" docapi:"example:John"`
+	Email   string        `json:"email" docapi:"example:john@mail.com"`
+	Address *benchAddress `json:"address"`
+	Orders  []benchOrder  `json:"orders"`
+}
+
+// newBenchDocs gera uma especificação grande, com vários endpoints usando os mesmos modelos.
+func newBenchDocs(paths int) *Docs {
+	docs := &Docs{Docs: make(map[string]*Doc)}
+	doc := docs.NewDoc("/swagger/doc.json")
+
+	for i := range paths {
+		NewDefaultPathStructure(doc, "get", fmt.Sprintf("/users/%d", i), testHandler, SecurityNone).
+			ResponseBodyJson(http.StatusOK, "ok", []benchUser{}).
+			ResponseBodyJson(http.StatusNotFound, "not found", benchOrder{})
+	}
+
+	return docs
+}
+
+// BenchmarkGetJSON serialização do doc.json com 500 endpoints.
+//
+// Comparação com o token UUID removido no commit 2474ddc (o benchmark foi adicionado no mesmo commit):
+//
+//	git worktree add /tmp/before 2474ddc^
+//	git show 2474ddc:docs_test.go > /tmp/before/docs_test.go
+//	cd /tmp/before && go test -run xxx -bench GetJSON -benchmem -count 3
+//
+//	antes (2474ddc^): 21.4-24.7 ms/op  9.59 MB/op  13.7k allocs/op
+//	depois (2474ddc): 12.9-18.1 ms/op  3.28 MB/op  13.8k allocs/op
+//
+// O tempo varia entre as execuções, a redução de memória é o resultado estável.
+func BenchmarkGetJSON(b *testing.B) {
+	docs := newBenchDocs(500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		docs.GetJSON("/swagger/doc.json")
+	}
+}
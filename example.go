@@ -3,14 +3,20 @@ package docapi
 type Examples map[string]*Example
 
 type Example struct {
-	// Tokens usado para manter ordenado o Value, conforme sequência de campos da struct modelo (dto).
-	// No GET do endpoint doc.json é removido o token do payload.
-	Tokens  [][]byte `json:"-"`
-	Summary string   `json:"summary,omitempty"`
-	Value   any      `json:"value,omitempty"`
-	Ref     string   `json:"$ref,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       any    `json:"value,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
 	// TypeName usado quando o modelo(dto) foi criado com reflect, neste caso não tem o nome da struct.
 	TypeName string `json:"-"`
+	// named exemplos nomeados do corpo, ver WithNamedExample.
+	named []namedExample
+}
+
+type namedExample struct {
+	name  string
+	value any
+	opts  []OptsExample
 }
 
 type OptsExample func(*Example)
@@ -21,6 +27,20 @@ func WithExampleSummary(summary string) OptsExample {
 	}
 }
 
+func WithExampleDescription(description string) OptsExample {
+	return func(e *Example) {
+		e.Description = description
+	}
+}
+
+// WithNamedExample adiciona um exemplo nomeado ao corpo, podendo ser informado várias vezes (ex.: admin, guest).
+// O valor é serializado e registrado em components/examples, as opções definem o summary e a description.
+func WithNamedExample(name string, value any, opts ...OptsExample) OptsExample {
+	return func(e *Example) {
+		e.named = append(e.named, namedExample{name: name, value: value, opts: opts})
+	}
+}
+
 // WithTypeName é usado quando a struct é criada via reflect, neste caso não tem o nome dela.
 func WithTypeName(typeName string) OptsExample {
 	return func(e *Example) {
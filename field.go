@@ -0,0 +1,247 @@
+package docapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// field representa um campo serializado da struct, já considerando os campos promovidos
+// das structs embutidas (anônimas).
+type field struct {
+	name string
+	// tag indica que o nome foi definido na tag json.
+	tag bool
+	// omitEmpty opção omitempty da tag json.
+	omitEmpty bool
+	// quoted opção string da tag json, o valor é serializado como string.
+	quoted bool
+	// allOf struct embutida documentada com allOf, ver isAllOf.
+	allOf bool
+	index []int
+	typ   reflect.Type
+	// structField campo original, usado para ler as tags.
+	structField reflect.StructField
+}
+
+// typeFields responsável por retornar os campos que o encoding/json serializa para o tipo t,
+// seguindo as mesmas regras de promoção e sombreamento de campos de structs embutidas.
+//
+// https://pkg.go.dev/encoding/json#Marshal
+func typeFields(t reflect.Type) []field {
+	var (
+		current []field
+		next    = []field{{typ: t}}
+
+		// Quantidade de vezes que o tipo aparece no nível atual e no próximo.
+		count     map[reflect.Type]int
+		nextCount = map[reflect.Type]int{}
+
+		// Tipos já visitados em níveis anteriores.
+		visited = map[reflect.Type]bool{}
+
+		fields []field
+		allOf  []field
+	)
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+
+			for i := 0; i < f.typ.NumField(); i++ {
+				sf := f.typ.Field(i)
+
+				if sf.Anonymous {
+					t := sf.Type
+					if t.Kind() == reflect.Pointer {
+						t = t.Elem()
+					}
+
+					// Struct embutida não exportada ainda tem os campos exportados promovidos.
+					if !sf.IsExported() && t.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name, opts := parseTagJson(tag)
+				if !isValidTag(name) {
+					name = ""
+				}
+
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				// Campo comum ou struct embutida com nome na tag json (não é promovida).
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					tagged := name != ""
+					if name == "" {
+						name = sf.Name
+					}
+
+					// A opção string só é aplicada em tipos escalares.
+					quoted := false
+					if opts.contains("string") {
+						switch ft.Kind() {
+						case reflect.Bool,
+							reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+							reflect.Float32, reflect.Float64,
+							reflect.String:
+							quoted = true
+						}
+					}
+
+					fields = append(fields, field{
+						name:        name,
+						tag:         tagged,
+						omitEmpty:   opts.contains("omitempty"),
+						quoted:      quoted,
+						index:       index,
+						typ:         sf.Type,
+						structField: sf,
+					})
+
+					// Quando o tipo aparece mais de uma vez no mesmo nível, o campo é duplicado
+					// para ser descartado na verificação de conflito.
+					if count[f.typ] > 1 {
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// Struct embutida documentada com allOf, os campos não são promovidos no schema.
+				if isAllOf(sf) {
+					allOf = append(allOf, field{name: ft.Name(), allOf: true, index: index, typ: sf.Type, structField: sf})
+					continue
+				}
+
+				// Struct embutida, os campos serão promovidos no próximo nível.
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, field{name: ft.Name(), index: index, typ: ft})
+				}
+			}
+		}
+	}
+
+	// Ordena por nome, profundidade, nome definido na tag e sequência do campo.
+	sort.Slice(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tag != x[j].tag {
+			return x[i].tag
+		}
+		return lessIndex(x[i].index, x[j].index)
+	})
+
+	// Remove os campos sombreados conforme as regras do Go para campos embutidos.
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		fi := fields[i]
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fi.name {
+				break
+			}
+		}
+
+		if advance == 1 {
+			out = append(out, fi)
+			continue
+		}
+
+		if dominant, ok := dominantField(fields[i : i+advance]); ok {
+			out = append(out, dominant)
+		}
+	}
+
+	// Mantém a sequência de declaração dos campos.
+	fields = append(out, allOf...)
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+
+	return fields
+}
+
+// dominantField retorna o campo que prevalece entre os campos com o mesmo nome,
+// que já estão ordenados por profundidade e tag. Caso haja empate, nenhum campo é serializado.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tag == fields[1].tag {
+		return field{}, false
+	}
+	return fields[0], true
+}
+
+func lessIndex(a, b []int) bool {
+	for k, v := range a {
+		if k >= len(b) {
+			return false
+		}
+		if v != b[k] {
+			return v < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// tagOptions opções da tag json, após o nome.
+type tagOptions string
+
+// parseTagJson separa o nome e as opções da tag json. Ex.: `json:"name,omitempty"`
+func parseTagJson(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, tagOptions(opts)
+}
+
+func (o tagOptions) contains(optionName string) bool {
+	s := string(o)
+	for s != "" {
+		var name string
+		name, s, _ = strings.Cut(s, ",")
+		if name == optionName {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidTag valida o nome da tag json, da mesma forma que o encoding/json.
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Pontuação permitida no nome.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package docapi
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeFormat formato OpenAPI e limites de valor do tipo Go.
+//
+// https://swagger.io/docs/specification/data-models/data-types/
+type typeFormat struct {
+	format  string
+	minimum *float64
+	maximum *float64
+}
+
+var kindFormats = map[reflect.Kind]typeFormat{
+	reflect.Int:   {format: "int64"},
+	reflect.Int8:  {format: "int32", minimum: float(math.MinInt8), maximum: float(math.MaxInt8)},
+	reflect.Int16: {format: "int32", minimum: float(math.MinInt16), maximum: float(math.MaxInt16)},
+	reflect.Int32: {format: "int32"},
+	reflect.Int64: {format: "int64"},
+	// uint e uint64 podem ultrapassar o int64, não há formato OpenAPI correspondente.
+	reflect.Uint:    {minimum: float(0)},
+	reflect.Uint8:   {format: "int32", minimum: float(0), maximum: float(math.MaxUint8)},
+	reflect.Uint16:  {format: "int32", minimum: float(0), maximum: float(math.MaxUint16)},
+	reflect.Uint32:  {format: "int64", minimum: float(0), maximum: float(math.MaxUint32)},
+	reflect.Uint64:  {minimum: float(0)},
+	reflect.Float32: {format: "float"},
+	reflect.Float64: {format: "double"},
+}
+
+// formatOf retorna o formato OpenAPI do tipo Go.
+func formatOf(t reflect.Type) typeFormat {
+	switch {
+	case t == timeType:
+		return typeFormat{format: "date-time"}
+	case isUUID(t):
+		return typeFormat{format: "uuid"}
+	case isBytes(t):
+		return typeFormat{format: "byte"}
+	}
+
+	return kindFormats[t.Kind()]
+}
+
+// isStringFormat indica os tipos que não são primitivos, mas são serializados como string.
+func isStringFormat(t reflect.Type) bool {
+	return t == timeType || isUUID(t) || isBytes(t)
+}
+
+// isBytes []byte é serializado pelo encoding/json como string base64.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// uuidTypes tipos UUID de bibliotecas conhecidas, identificados pelo pacote e nome sem importar a dependência.
+// Outros tipos devem ser registrados via AddTypeOverride.
+var uuidTypes = map[string]bool{
+	"github.com/google/uuid.UUID":    true,
+	"github.com/gofrs/uuid.UUID":     true,
+	"github.com/gofrs/uuid/v5.UUID":  true,
+	"github.com/satori/go.uuid.UUID": true,
+}
+
+// isUUID indica os tipos UUID conhecidos, ver uuidTypes.
+func isUUID(t reflect.Type) bool {
+	return t.PkgPath() != "" && uuidTypes[t.PkgPath()+"."+t.Name()]
+}
+
+func float(v float64) *float64 {
+	return &v
+}
@@ -5,6 +5,5 @@ go 1.23.0
 require github.com/go-chi/chi/v5 v5.1.0
 
 require (
-	github.com/google/uuid v1.6.0
 	github.com/swaggo/files/v2 v2.0.1 // indirect
 )
@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 
 	files "github.com/swaggo/files/v2"
 )
@@ -27,7 +29,35 @@ type HTMLConfig struct {
 	DefaultModelsExpandDepth int
 }
 
-func HandlerFunc(urlDocJson string) http.HandlerFunc {
+// OptsHandler opções de entrega do doc.json.
+type OptsHandler func(*handlerOptions)
+
+type handlerOptions struct {
+	cacheControl string
+	compressions []Compression
+}
+
+// WithCacheControl define o Cache-Control do doc.json, o padrão é no-cache (revalidado via ETag).
+func WithCacheControl(cacheControl string) OptsHandler {
+	return func(o *handlerOptions) {
+		o.cacheControl = cacheControl
+	}
+}
+
+// WithCompression comprime o doc.json conforme o Accept-Encoding da requisição, na ordem de preferência informada.
+// Ex.: WithCompression(docapi.Gzip) ou um encoding externo como o br.
+func WithCompression(compressions ...Compression) OptsHandler {
+	return func(o *handlerOptions) {
+		o.compressions = append(o.compressions, compressions...)
+	}
+}
+
+func HandlerFunc(urlDocJson string, opts ...OptsHandler) http.HandlerFunc {
+	options := &handlerOptions{cacheControl: "no-cache"}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	config := &HTMLConfig{
 		URL:                      urlDocJson,
 		DocExpansion:             "list",
@@ -59,7 +89,7 @@ func HandlerFunc(urlDocJson string) http.HandlerFunc {
 			index.Execute(w, config)
 
 		case "doc.json":
-			w.Write(GetDocs().GetJSON(r.URL.Path))
+			serveDocJSON(w, r, options)
 
 		case "":
 			http.Redirect(w, r, submatch[1]+"/"+"index.html", http.StatusMovedPermanently)
@@ -78,6 +108,48 @@ func HandlerFunc(urlDocJson string) http.HandlerFunc {
 	}
 }
 
+// serveDocJSON entrega o doc.json serializado com ETag, respondendo 304 quando o cliente já possui a versão atual.
+func serveDocJSON(w http.ResponseWriter, r *http.Request, options *handlerOptions) {
+	doc, ok := GetDocs().FindDocJSONByPath(strings.TrimSuffix(r.URL.Path, "/doc.json"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	cache, err := doc.serialized()
+	if err != nil {
+		slog.Error("error when creating Swagger doc.json file.", "error", err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	body, etag := cache.json, cache.etag
+
+	if len(options.compressions) > 0 {
+		w.Header().Set("Vary", "Accept-Encoding")
+
+		if compression, ok := acceptEncoding(r.Header.Get("Accept-Encoding"), options.compressions); ok {
+			if encoded, encodedEtag, err := cache.encode(compression); err != nil {
+				slog.Error("error when compressing Swagger doc.json file.", "error", err.Error())
+			} else {
+				body, etag = encoded, encodedEtag
+				w.Header().Set("Content-Encoding", compression.Encoding)
+			}
+		}
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", options.cacheControl)
+
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Write(body)
+}
+
 func getContextType(path string) (ct string) {
 	switch filepath.Ext(path) {
 	case ".html":
@@ -0,0 +1,55 @@
+package docapi
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandlerFuncDocJSONCache(t *testing.T) {
+	api := NewDocApi("localhost:8080/cache-test")
+	api.NewRouter().Get("/users", testHandler)
+
+	_, handler := api.HandlerFunc(WithCompression(Gzip))
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/cache-test/doc.json", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+
+	w := get("", "")
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("expected 200 with ETag and Cache-Control but we got %d %v", w.Code, w.Header())
+	}
+
+	if w = get("If-None-Match", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected 304 without body but we got %d", w.Code)
+	}
+
+	w = get("Accept-Encoding", "br;q=1.0, gzip;q=0.8")
+	if w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("ETag") == etag {
+		t.Fatalf("expected gzip with its own ETag but we got %v", w.Header())
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := io.ReadAll(gz); string(body) != string(GetDocs().GetJSON("/cache-test/doc.json")) {
+		t.Errorf("expected gzip body equal to doc.json")
+	}
+
+	api.NewRouter().Get("/orders", testHandler)
+
+	if w = get("If-None-Match", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected new ETag after adding a route but we got %d %s", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,156 @@
+package docapi
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SchemaNamer estratégia que define o nome do schema em components/schemas, usado no $ref.
+type SchemaNamer func(t reflect.Type) string
+
+// DefaultSchemaName nome do tipo, em tipos genéricos os argumentos são concatenados sem o pacote.
+//
+// Ex.: Page[github.com/app/model.User] → PageUser
+func DefaultSchemaName(t reflect.Type) string {
+	return genericSchemaName(t.Name(), false)
+}
+
+// PackageSchemaName nome do tipo prefixado com o nome do pacote, evitando conflito entre tipos
+// com o mesmo nome em pacotes diferentes.
+//
+// Ex.: user.Response → UserResponse; order.Page[github.com/app/model.User] → OrderPageModelUser
+func PackageSchemaName(t reflect.Type) string {
+	name := upperFirst(genericSchemaName(t.Name(), true))
+	if t.PkgPath() == "" {
+		return name
+	}
+	return upperFirst(path.Base(t.PkgPath())) + name
+}
+
+// SchemaCollisionError tipos diferentes com o mesmo nome de schema (ex.: user.Response e order.Response),
+// que não foi possível resolver com o nome do pacote.
+type SchemaCollisionError struct {
+	Name  string
+	Types []reflect.Type
+}
+
+func (e *SchemaCollisionError) Error() string {
+	return fmt.Sprintf("docapi: schema name %q collision between types %v", e.Name, e.Types)
+}
+
+// Err retorna os erros encontrados ao gerar os schemas, ex.: SchemaCollisionError.
+func (c *Components) Err() error {
+	return errors.Join(c.errs...)
+}
+
+// schemaName retorna o nome do schema de t conforme a estratégia configurada, ver uniqueSchemaName.
+func (c *Components) schemaName(t reflect.Type) string {
+	name := DefaultSchemaName(t)
+	if c.SchemaNamer != nil {
+		name = c.SchemaNamer(t)
+	}
+
+	return c.uniqueSchemaName(t, name)
+}
+
+// uniqueSchemaName reserva o nome do schema para t, comparando a identidade do tipo.
+//
+// Quando o nome já está em uso por outro tipo é usado o nome com o pacote (PackageSchemaName),
+// caso ainda haja conflito o erro é registrado (SchemaCollisionError) e é adicionado um sufixo numérico,
+// evitando que o schema de um tipo sobrescreva o do outro.
+func (c *Components) uniqueSchemaName(t reflect.Type, name string) string {
+	if name == "" {
+		return name
+	}
+
+	key := schemaKey{t: t, name: name}
+	if reserved, ok := c.typeNames[key]; ok {
+		return reserved
+	}
+
+	if c.nameTypes == nil {
+		c.nameTypes = make(map[string]reflect.Type, 1)
+		c.typeNames = make(map[schemaKey]string, 1)
+	}
+
+	used, ok := c.nameTypes[name]
+	if ok && used != t {
+		pkgName := PackageSchemaName(t)
+
+		if other, ok := c.nameTypes[pkgName]; pkgName != "" && (!ok || other == t) {
+			name = pkgName
+		} else {
+			err := &SchemaCollisionError{Name: name, Types: []reflect.Type{used, t}}
+			slog.Error("[DocApi]", "error", err.Error())
+			c.errs = append(c.errs, err)
+
+			base := name
+			for i := 2; c.nameTypes[name] != nil; i++ {
+				name = base + strconv.Itoa(i)
+			}
+		}
+	}
+
+	c.nameTypes[name] = t
+	c.typeNames[key] = name
+
+	return name
+}
+
+// schemaKey tipo e nome solicitado, usado para manter o mesmo nome reservado nas próximas chamadas.
+type schemaKey struct {
+	t    reflect.Type
+	name string
+}
+
+// genericSchemaName remove os caracteres inválidos para a chave de components/schemas
+// (^[a-zA-Z0-9\.\-_]+$) do nome de tipos genéricos, mantendo somente o nome dos argumentos.
+// Caracteres fora do ASCII são substituídos por "_".
+func genericSchemaName(name string, withPackage bool) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '[' || r == ']' || r == ',' || r == '*' || r == ' '
+	})
+
+	var b strings.Builder
+	for i, part := range parts {
+		pkg := ""
+		// github.com/app/model.User → model, User
+		if dot := strings.LastIndex(part, "."); dot >= 0 {
+			pkg, part = path.Base(part[:dot]), part[dot+1:]
+		}
+
+		if i > 0 {
+			if withPackage {
+				b.WriteString(upperFirst(pkg))
+			}
+			part = upperFirst(part)
+		}
+
+		for _, r := range part {
+			switch {
+			case r == '_' || r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+				b.WriteRune(r)
+			case r > unicode.MaxASCII:
+				// Letras acentuadas não são válidas na chave, ex.: Endereço → Endere_o
+				b.WriteRune('_')
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,80 @@
+package docapi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// closureName funções anônimas recebem o nome func1, func2... do runtime.
+var closureName = regexp.MustCompile(`^func\d+$`)
+
+// OperationID define o operationId do endpoint, usado nos links do Swagger UI e no nome dos métodos dos clientes gerados.
+// Deve ser único no doc.json, quando já estiver em uso é adicionado um sufixo numérico e o erro é reportado no Validate.
+func (p *PathsStructure) OperationID(id string) PathStructure {
+	defer p.Doc.lock()()
+
+	if p.Doc == nil {
+		p.OpId = id
+		return p
+	}
+
+	p.operationID = id
+
+	delete(p.Doc.operationIDs, p.OpId)
+	p.OpId = p.Doc.reserveOperationID(id, p)
+	return p
+}
+
+// reserveOperationID reserva o operationId para o endpoint, adicionando um sufixo numérico quando já está em uso.
+// Deve ser chamado com a documentação bloqueada.
+func (j *Doc) reserveOperationID(id string, p *PathsStructure) string {
+	if id == "" {
+		return id
+	}
+
+	if j.operationIDs == nil {
+		j.operationIDs = make(map[string]*PathsStructure, 1)
+	}
+
+	unique := id
+	for i := 2; j.operationIDs[unique] != nil && j.operationIDs[unique] != p; i++ {
+		unique = id + strconv.Itoa(i)
+	}
+
+	j.operationIDs[unique] = p
+	return unique
+}
+
+// defaultOperationID operationId derivado do nome da função do controller (runtime.FuncForPC).
+//
+// Ex.: github.com/app/controller.GetUser → GetUser; controller.(*User).Get-fm → User_Get.
+// Funções anônimas usam o método e o path: GET /users/{id} → getUsersId.
+func defaultOperationID(funcName, method, path string) string {
+	funcName = funcName[strings.LastIndex(funcName, "/")+1:]
+	funcName = strings.TrimSuffix(funcName, "-fm")
+
+	parts := strings.Split(funcName, ".")
+	name := parts[len(parts)-1]
+
+	if len(parts) > 1 && !closureName.MatchString(name) {
+		// Métodos incluem o tipo do receiver, controllers diferentes costumam repetir Get, List...
+		if len(parts) > 2 {
+			name = strings.Trim(parts[len(parts)-2], "(*)") + "_" + name
+		}
+
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(upperFirst(segment))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package docapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OrderedMap mapa que mantém a ordem de inserção das chaves na serialização,
+// usado nas properties do schema e no exemplo conforme a sequência de campos da struct modelo (dto).
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func NewOrderedMap(size int) *OrderedMap {
+	return &OrderedMap{
+		keys:   make([]string, 0, size),
+		values: make(map[string]any, size),
+	}
+}
+
+// Set adiciona ou substitui o valor da chave, a chave existente mantém a posição original.
+func (m *OrderedMap) Set(key string, value any) {
+	if m.values == nil {
+		m.values = make(map[string]any, 1)
+	}
+
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap) Get(key string) (value any, ok bool) {
+	value, ok = m.values[key]
+	return
+}
+
+// Keys retorna as chaves na ordem de inserção.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+func (m OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,48 @@
+package docapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderedMapMarshalJSON(t *testing.T) {
+	m := NewOrderedMap(3)
+	m.Set("name", "zz$token")
+	m.Set("id", 1)
+	m.Set("address", NewOrderedMap(0))
+	m.Set("name", "john")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"name":"john","id":1,"address":{}}`; string(b) != expected {
+		t.Errorf("expected %s but we got %s", expected, b)
+	}
+}
+
+func TestGetJSONFieldOrder(t *testing.T) {
+	docs := newBenchDocs(1)
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(docs.GetJSON("/swagger/doc.json"), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(doc.Components.Schemas["benchAddress"], &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"street":{"type":"string"},"city":{"type":"string"},"country":{"type":"string"}}`
+	if string(schema.Properties) != expected {
+		t.Errorf("expected properties in field order %s but we got %s", expected, schema.Properties)
+	}
+}
@@ -0,0 +1,63 @@
+package docapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// typeOverride schema e exemplo fixos de um tipo, usado por tipos de terceiros que não podem
+// implementar SchemaProvider (ex.: decimal.Decimal, null.Time).
+type typeOverride struct {
+	schema  *Schema
+	example any
+}
+
+// builtinOverrides tipos da biblioteca padrão que não devem ser documentados conforme os campos da struct.
+// Tipos UUID de bibliotecas conhecidas são identificados pelo pacote, ver uuidTypes.
+//
+// Os tipos sql.Null* não são registrados, o encoding/json os serializa como objeto ({"String": "x", "Valid": true}).
+var builtinOverrides = map[reflect.Type]typeOverride{
+	reflect.TypeOf(json.RawMessage{}): {&Schema{}, map[string]any{}},
+	// time.Duration é serializado como número inteiro de nanosegundos.
+	durationType: {&Schema{Type: DataTypeInteger, Format: "int64", Description: "nanoseconds"}, int64(time.Second)},
+}
+
+// AddTypeOverride registra o schema e o exemplo fixos de t, que prevalecem sobre a navegação do tipo.
+func (c *Components) AddTypeOverride(t reflect.Type, schema *Schema, example any) {
+	if t == nil || schema == nil {
+		return
+	}
+
+	if c.overrides == nil {
+		c.overrides = make(map[reflect.Type]typeOverride, 1)
+	}
+
+	c.overrides[indirectType(t)] = typeOverride{schema: schema, example: example}
+}
+
+// fixedSchema retorna uma cópia do schema fixo do tipo, registrado (AddTypeOverride e padrões)
+// ou informado pelo próprio tipo (SchemaProvider), e o exemplo.
+func (c *Components) fixedSchema(t reflect.Type) (schema *Schema, example any, ok bool) {
+	override, ok := c.overrides[t]
+	if !ok {
+		override, ok = builtinOverrides[t]
+	}
+
+	if ok {
+		schemaCopy := *override.schema
+		example = override.example
+		if example == nil {
+			example = defaultExample(schemaCopy.Type)
+		}
+		return &schemaCopy, example, true
+	}
+
+	if schema, ok = providedSchema(t); ok {
+		return schema, defaultExample(schema.Type), true
+	}
+
+	return nil, nil, false
+}
@@ -20,8 +20,11 @@ type Parameter struct {
 	In          ParamIn `json:"in,omitempty"`
 	Name        string  `json:"name,omitempty"`
 	Description string  `json:"description,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
 	Example     string  `json:"example,omitempty"`
 	ParamSchema *Schema `json:"schema,omitempty"`
+	// fromPattern parâmetro criado a partir do pattern da rota, ainda não detalhado via ParamPath.
+	fromPattern bool
 }
 
 type OptsParameter func(*Parameter)
@@ -38,6 +41,12 @@ func WithParamDescription(description string) OptsParameter {
 	}
 }
 
+func WithParamDeprecated() OptsParameter {
+	return func(p *Parameter) {
+		p.Deprecated = true
+	}
+}
+
 func WithParamExample(example string) OptsParameter {
 	return func(p *Parameter) {
 		p.Example = example
@@ -55,6 +64,8 @@ const (
 	DataTypeInteger DataType = "integer"
 	DataTypeNumber  DataType = "number"
 	DataTypeObject  DataType = "object"
+	// DataTypeNull usado somente no OpenAPI 3.1.
+	DataTypeNull DataType = "null"
 )
 
 func (s DataType) String() string {
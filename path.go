@@ -5,12 +5,23 @@ import (
 	"net/http"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type PathStructure interface {
 	Tag(string) PathStructure
+	// AddTags adiciona as tags no endpoint, a tag padrão (nome do arquivo do controller) é substituída na primeira chamada.
+	AddTags(...string) PathStructure
 	Summary(string) PathStructure
+	OperationID(string) PathStructure
+	Deprecated() PathStructure
+	// DeprecatedSince marca o endpoint como deprecated e informa a data da descontinuação (header Deprecation).
+	DeprecatedSince(time.Time) PathStructure
+	// Sunset marca o endpoint como deprecated e informa a data de desativação (x-sunset).
+	Sunset(time.Time) PathStructure
 	Description(string) PathStructure
 	ParamPath(name string, dataType DataType, opts ...OptsParameter) PathStructure
 	ParamQuery(name string, dataType DataType, opts ...OptsParameter) PathStructure
@@ -27,27 +38,43 @@ type PathStructure interface {
 
 func NewDefaultPathStructure(doc *Doc, method, pattern string, handlerFn http.HandlerFunc, security SecurityType) PathStructure {
 	tag := "default"
+	funcName := runtime.FuncForPC(reflect.ValueOf(handlerFn).Pointer()).Name()
 
 	// Obtém o nome do arquivo que está o controller
-	s := strings.Split(runtime.FuncForPC(reflect.ValueOf(handlerFn).Pointer()).Name(), "/")
+	s := strings.Split(funcName, "/")
 	if len(s) > 0 {
 		tag = strings.Split(s[len(s)-1], ".")[0]
 	}
 
+	path, params := parsePattern(pattern)
+
 	p := &PathsStructure{
 		Doc:       doc,
 		Method:    method,
 		Pattern:   pattern,
+		Path:      path,
+		OpId:      defaultOperationID(funcName, method, path),
 		H:         handlerFn,
 		Tags:      []string{tag},
 		Responses: map[string]*Response{"default": {Description: "Default"}},
 	}
 
+	// Parâmetros do pattern são obrigatórios, podem ser detalhados via ParamPath.
+	for _, param := range params {
+		p.Parameters = append(p.Parameters, &Parameter{
+			Required:    true,
+			In:          ParamPath,
+			Name:        param.name,
+			ParamSchema: &Schema{Type: DataTypeString, Pattern: param.regex},
+			fromPattern: true,
+		})
+	}
+
 	if security != SecurityNone {
 		p.Security = append(p.Security, PathSecurity{security.String(): []string{}})
 	}
 
-	doc.AddPath(method, pattern, p)
+	doc.AddPath(method, path, p)
 	return p
 }
 
@@ -58,37 +85,97 @@ var _ PathStructure = (*PathsStructure)(nil)
 
 // https://swagger.io/docs/specification/paths-and-operations/
 type PathsStructure struct {
-	Doc         *Doc             `json:"-"`
-	Method      string           `json:"-"`
-	Pattern     string           `json:"-"`
-	H           http.HandlerFunc `json:"-"`
-	Tags        []string         `json:"tags,omitempty"`
-	Summ        string           `json:"summary,omitempty"`
-	Desc        string           `json:"description,omitempty"`
-	Security    []PathSecurity   `json:"security,omitempty"`
-	Parameters  []*Parameter     `json:"parameters,omitempty"`
-	RequestBody *ResquestBody    `json:"requestBody,omitempty"`
+	Doc     *Doc   `json:"-"`
+	Method  string `json:"-"`
+	Pattern string `json:"-"`
+	// Path documentado no formato do OpenAPI, ex.: /users/{id:[0-9]+} → /users/{id}
+	Path   string           `json:"-"`
+	H      http.HandlerFunc `json:"-"`
+	Tags   []string         `json:"tags,omitempty"`
+	OpId   string           `json:"operationId,omitempty"`
+	Summ   string           `json:"summary,omitempty"`
+	Desc   string           `json:"description,omitempty"`
+	Deprec bool             `json:"deprecated,omitempty"`
+	// XSunset data de desativação do endpoint (RFC 8594), ver Sunset.
+	XSunset     string         `json:"x-sunset,omitempty"`
+	Security    []PathSecurity `json:"security,omitempty"`
+	Parameters  []*Parameter   `json:"parameters,omitempty"`
+	RequestBody *ResquestBody  `json:"requestBody,omitempty"`
 	// A chave representa o http status code (200, 201,..., 400,...)
 	Responses map[string]*Response `json:"responses"`
+	// deprecation datas dos headers Deprecation e Sunset, lidas pelo controller em cada requisição sem o lock do Doc.
+	deprecation atomic.Pointer[deprecationState]
+	// operationID informado no OperationID, antes do sufixo numérico, usado no Validate para reportar a duplicidade.
+	operationID string
+	// customTags indica que a tag padrão já foi substituída, ver AddTags.
+	customTags bool
 }
 
 type PathSecurity map[string][]string
 
 func (p *PathsStructure) Tag(tag string) PathStructure {
+	defer p.Doc.lock()()
+
 	p.Tags = []string{tag}
+	p.customTags = true
+	return p
+}
+
+func (p *PathsStructure) AddTags(tags ...string) PathStructure {
+	defer p.Doc.lock()()
+
+	if !p.customTags {
+		p.Tags = nil
+		p.customTags = true
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(p.Tags, tag) {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
 	return p
 }
 
 func (p *PathsStructure) Summary(summary string) PathStructure {
+	defer p.Doc.lock()()
+
 	p.Summ = summary
 	return p
 }
 
 func (p *PathsStructure) Description(description string) PathStructure {
+	defer p.Doc.lock()()
+
 	p.Desc = description
 	return p
 }
 
+func (p *PathsStructure) Deprecated() PathStructure {
+	defer p.Doc.lock()()
+
+	p.Deprec = true
+	p.deprecate(func(*deprecationState) {})
+	return p
+}
+
+func (p *PathsStructure) DeprecatedSince(date time.Time) PathStructure {
+	defer p.Doc.lock()()
+
+	p.Deprec = true
+	p.deprecate(func(s *deprecationState) { s.deprecation = date })
+	return p
+}
+
+func (p *PathsStructure) Sunset(date time.Time) PathStructure {
+	defer p.Doc.lock()()
+
+	p.Deprec = true
+	p.deprecate(func(s *deprecationState) { s.sunset = date })
+	p.XSunset = date.UTC().Format(time.DateOnly)
+	return p
+}
+
 func (p *PathsStructure) ParamPath(name string, dataType DataType, opts ...OptsParameter) PathStructure {
 	p.addParameter(ParamPath, name, dataType, opts...)
 	return p
@@ -110,6 +197,25 @@ func (p *PathsStructure) ParamCookie(name string, dataType DataType, opts ...Opt
 }
 
 func (p *PathsStructure) addParameter(in ParamIn, name string, sType DataType, opts ...OptsParameter) {
+	defer p.Doc.lock()()
+
+	// Parâmetro extraído do pattern é detalhado, mantendo o pattern da expressão regular somente em string,
+	// nos demais tipos o pattern é ignorado pelo OpenAPI.
+	for _, param := range p.Parameters {
+		if param.fromPattern && param.In == in && param.Name == name {
+			param.fromPattern = false
+			param.ParamSchema.Type = sType
+			if sType != DataTypeString {
+				param.ParamSchema.Pattern = ""
+			}
+
+			for _, fn := range opts {
+				fn(param)
+			}
+			return
+		}
+	}
+
 	param := &Parameter{
 		Name: name,
 		In:   in,
@@ -130,6 +236,8 @@ func (p *PathsStructure) RequestBodyJson(body any, opts ...OptsRequest) PathStru
 }
 
 func (p *PathsStructure) setRequest(contentType string, body any, opts ...OptsRequest) PathStructure {
+	defer p.Doc.lock()()
+
 	if strings.TrimSpace(contentType) == "" {
 		contentType = "*/*"
 	}
@@ -146,6 +254,10 @@ func (p *PathsStructure) setRequest(contentType string, body any, opts ...OptsRe
 		p.RequestBody.Content = NewContentType(contentType, content)
 	}
 
+	// Os exemplos nomeados são somente do corpo informado nesta chamada, os exemplos já adicionados no content
+	// permanecem, assim como o corpo no oneOf.
+	p.RequestBody.namedExamples = nil
+
 	for _, fn := range opts {
 		fn(p.RequestBody)
 	}
@@ -164,6 +276,8 @@ func (p *PathsStructure) setRequest(contentType string, body any, opts ...OptsRe
 		optsExample = append(optsExample, WithTypeName(p.RequestBody.typeName))
 	}
 
+	optsExample = append(optsExample, p.RequestBody.namedExamples...)
+
 	return p.parseBody(content, body, optsExample...)
 }
 
@@ -180,6 +294,8 @@ func (p *PathsStructure) ResponseBodyJson(statusCode int, description string, bo
 }
 
 func (p *PathsStructure) addResponse(contentType string, statusCode int, description string, body any, opts ...OptsExample) PathStructure {
+	defer p.Doc.lock()()
+
 	if strings.TrimSpace(contentType) == "" {
 		contentType = "*/*"
 	}
@@ -222,16 +338,60 @@ func (p *PathsStructure) parseBody(content *Content, body any, opts ...OptsExamp
 		return p
 	}
 
-	modelValue := reflect.ValueOf(body)
-	modelType := modelValue.Type()
+	if union, ok := body.(*Union); ok {
+		return p.parseUnion(content, union, opts...)
+	}
+
+	modelValue, modelType, dataType, ok := modelOf(body)
+	if !ok {
+		return p
+	}
+
+	modelName := p.Doc.Components.AddSchemasAndExamples(modelValue, modelType, dataType, opts...)
+
+	content.Schemas.AddOneOfRef(modelName, dataType)
+
+	// Com exemplos nomeados o exemplo gerado do modelo não é referenciado no content.
+	if !p.Doc.Components.addNamedExamples(content, modelName, opts...) {
+		addBodyExample(content, modelName, body, opts...)
+	}
+
+	return p
+}
+
+// addBodyExample adiciona no content o exemplo do modelo. O corpo preenchido é usado como exemplo inline,
+// sem alterar o exemplo gerado em components/examples, que é compartilhado entre os endpoints.
+func addBodyExample(content *Content, modelName string, body any, opts ...OptsExample) {
+	if isEmptyBody(body) {
+		content.AddExamplesRef(modelName)
+		return
+	}
+
+	raw, ok := rawExample(body)
+	if !ok {
+		content.AddExamplesRef(modelName)
+		return
+	}
+
+	example := &Example{}
+	for _, fn := range opts {
+		fn(example)
+	}
+
+	content.addExample(modelName, &Example{Summary: example.Summary, Description: example.Description, Value: raw})
+}
+
+// modelOf responsável por extrair o valor e o tipo do modelo (dto) do corpo, quando é slice/array
+// retorna o tipo do elemento.
+func modelOf(body any) (modelValue reflect.Value, modelType reflect.Type, dataType DataType, ok bool) {
+	modelValue = reflect.ValueOf(body)
+	modelType = modelValue.Type()
 
 	if modelValue.Kind() == reflect.Pointer {
 		modelValue = modelValue.Elem()
 		modelType = modelType.Elem()
 	}
 
-	var dataType DataType
-
 	switch modelType.Kind() {
 	case reflect.Slice, reflect.Array:
 		dataType = DataTypeArray
@@ -244,21 +404,39 @@ func (p *PathsStructure) parseBody(content *Content, body any, opts ...OptsExamp
 	case reflect.Struct:
 		dataType = DataTypeObject
 	default:
-		return p
+		return
 	}
 
-	modelName := p.Doc.Components.AddSchemasAndExamples(modelValue, modelType, dataType, opts...)
+	return modelValue, modelType, dataType, true
+}
 
-	content.Schemas.AddOneOfRef(modelName, dataType)
-	content.AddExamplesRef(modelName)
+// isEmptyBody indica se o corpo não foi preenchido (valor zero, ponteiro nil ou slice vazio),
+// neste caso o exemplo é gerado a partir das tags.
+func isEmptyBody(body any) bool {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
 
-	return p
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
 }
 
 func (p *PathsStructure) MethodFunc() (method, pattern string, handlerFn http.HandlerFunc) {
-	return p.Method, p.Pattern, p.H
+	p.validateOnHandle()
+
+	return p.Method, p.Pattern, p.handler()
 }
 
 func (p *PathsStructure) HandleFunc() (methodAndPattern string, handlerFn http.HandlerFunc) {
-	return fmt.Sprint(strings.ToUpper(p.Method), " ", p.Pattern), p.H
+	p.validateOnHandle()
+
+	return fmt.Sprint(strings.ToUpper(p.Method), " ", p.Pattern), p.handler()
 }
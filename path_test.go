@@ -0,0 +1,374 @@
+package docapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCardPayment struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+type testPixPayment struct {
+	Type string `json:"type"`
+	Key  string `json:"key"`
+}
+
+func testHandler(w http.ResponseWriter, r *http.Request) {}
+
+func newTestDoc() *Doc {
+	return &Doc{Components: &Components{}}
+}
+
+func TestResponseBodyJsonOneOf(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "get", "/payments", testHandler, SecurityNone).
+		ResponseBodyJson(http.StatusOK, "ok", OneOf(testCardPayment{}, testPixPayment{}).
+			Discriminator("type").
+			Mapping("card", testCardPayment{}).
+			Mapping("pix", testPixPayment{}))
+
+	content := doc.Paths["/payments"]["get"].Responses["200"].Content["aplication/json"]
+
+	if len(content.Schemas.OneOf) != 2 {
+		t.Fatalf("expected 2 oneOf refs but we got %v", content.Schemas.OneOf)
+	}
+
+	discriminator := content.Schemas.Discriminator
+	if discriminator == nil || discriminator.PropertyName != "type" {
+		t.Fatalf("expected discriminator type but we got %+v", discriminator)
+	}
+
+	if ref := discriminator.Mapping["pix"]; ref != "#/components/schemas/testPixPayment" {
+		t.Errorf("expected mapping pix to testPixPayment but we got %s", ref)
+	}
+
+	for _, name := range []string{"testCardPayment", "testPixPayment"} {
+		if _, ok := content.Examples[name]; !ok {
+			t.Errorf("expected example %s in content", name)
+		}
+
+		if _, ok := doc.Components.Examples[name]; !ok {
+			t.Errorf("expected example %s in components", name)
+		}
+	}
+}
+
+func TestResponseBodyJsonSample(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "get", "/payments", testHandler, SecurityNone).
+		ResponseBodyJson(http.StatusOK, "ok", []testPixPayment{{Type: "pix", Key: "user@mail.com"}}).
+		ResponseBodyJson(http.StatusCreated, "created", testCardPayment{})
+
+	// O mesmo tipo registrado novamente, vazio e com outro valor, não altera o exemplo do primeiro endpoint.
+	NewDefaultPathStructure(doc, "post", "/payments", testHandler, SecurityNone).
+		RequestBodyJson(testPixPayment{}).
+		ResponseBodyJson(http.StatusOK, "ok", testPixPayment{Type: "pix", Key: "+5511999999999"})
+
+	responses := doc.Paths["/payments"]["get"].Responses
+	inline := responses["200"].Content["aplication/json"].Examples["testPixPayment"]
+	if expected := `[{"type":"pix","key":"user@mail.com"}]`; inline == nil || string(inline.Value.(json.RawMessage)) != expected {
+		t.Errorf("expected inline example %s but we got %+v", expected, inline)
+	}
+
+	post := doc.Paths["/payments"]["post"].Responses["200"].Content["aplication/json"].Examples["testPixPayment"]
+	if expected := `{"type":"pix","key":"+5511999999999"}`; post == nil || string(post.Value.(json.RawMessage)) != expected {
+		t.Errorf("expected inline example %s but we got %+v", expected, post)
+	}
+
+	if ref := responses["201"].Content["aplication/json"].Examples["testCardPayment"].Ref; ref != "#/components/examples/testCardPayment" {
+		t.Errorf("expected generated example ref for zero value body but we got %s", ref)
+	}
+
+	if _, ok := doc.Components.Examples["testPixPayment"].Value.(json.RawMessage); ok {
+		t.Error("expected generated example in components/examples")
+	}
+}
+
+func TestResponseBodyJsonNamedExample(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "post", "/payments", testHandler, SecurityNone).
+		RequestBodyJson(testPixPayment{}, WithReqNamedExample("email key", testPixPayment{Type: "pix", Key: "user@mail.com"})).
+		ResponseBodyJson(http.StatusOK, "ok", testPixPayment{},
+			WithNamedExample("email", testPixPayment{Type: "pix", Key: "user@mail.com"}, WithExampleSummary("E-mail")),
+			WithNamedExample("phone", testPixPayment{Type: "pix", Key: "+5511999999999"}))
+
+	path := doc.Paths["/payments"]["post"]
+	content := path.Responses["200"].Content["aplication/json"]
+
+	if len(content.Examples) != 2 {
+		t.Fatalf("expected 2 named examples but we got %v", content.Examples)
+	}
+
+	if ref := content.Examples["email"].Ref; ref != "#/components/examples/testPixPayment.email" {
+		t.Errorf("expected ref to testPixPayment.email but we got %s", ref)
+	}
+
+	if example := doc.Components.Examples["testPixPayment.email"]; example == nil || example.Summary != "E-mail" {
+		t.Errorf("expected named example with summary but we got %+v", example)
+	}
+
+	if ref := path.RequestBody.Content["aplication/json"].Examples["email key"].Ref; ref != "#/components/examples/testPixPayment.email_key" {
+		t.Errorf("expected ref to testPixPayment.email_key but we got %s", ref)
+	}
+}
+
+func TestRequestBodyJsonNamedExampleReset(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "post", "/payments", testHandler, SecurityNone).
+		RequestBodyJson(testPixPayment{}, WithReqNamedExample("pix", testPixPayment{Type: "pix", Key: "user@mail.com"})).
+		RequestBodyJson(testCardPayment{}, WithReqNamedExample("card", testCardPayment{Type: "card", Number: "4111"}))
+
+	content := doc.Paths["/payments"]["post"].RequestBody.Content["aplication/json"]
+	if len(content.Schemas.OneOf) != 2 {
+		t.Fatalf("expected 2 oneOf refs but we got %v", content.Schemas.OneOf)
+	}
+
+	expected := map[string]string{
+		"pix":  "#/components/examples/testPixPayment.pix",
+		"card": "#/components/examples/testCardPayment.card",
+	}
+	if len(content.Examples) != len(expected) {
+		t.Errorf("expected examples %v but we got %v", expected, content.Examples)
+	}
+
+	for name, ref := range expected {
+		if example := content.Examples[name]; example == nil || example.Ref != ref {
+			t.Errorf("expected example %s with ref %s but we got %+v", name, ref, example)
+		}
+	}
+
+	if _, ok := doc.Components.Examples["testCardPayment.pix"]; ok {
+		t.Error("expected named example of the first call not repeated in the second body")
+	}
+}
+
+func TestResponseBodyJsonOneOfNamedExample(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "get", "/payments", testHandler, SecurityNone).
+		OperationID("listPayments").
+		ResponseBodyJson(http.StatusOK, "ok", OneOf(testCardPayment{}, testPixPayment{}),
+			WithNamedExample("default", testCardPayment{Type: "card", Number: "4111"}))
+
+	NewDefaultPathStructure(doc, "get", "/refunds", func(w http.ResponseWriter, r *http.Request) {}, SecurityNone).
+		ResponseBodyJson(http.StatusOK, "ok", OneOf(testCardPayment{}, testPixPayment{}),
+			WithNamedExample("default", testPixPayment{Type: "pix", Key: "user@mail.com"}))
+
+	for path, key := range map[string]string{"/payments": "listPayments.default", "/refunds": "getRefunds.default"} {
+		if ref := doc.Paths[path]["get"].Responses["200"].Content["aplication/json"].Examples["default"].Ref; ref != "#/components/examples/"+key {
+			t.Errorf("expected ref to %s but we got %s", key, ref)
+		}
+	}
+
+	payments, refunds := doc.Components.Examples["listPayments.default"], doc.Components.Examples["getRefunds.default"]
+	if payments == nil || refunds == nil || string(payments.Value.(json.RawMessage)) == string(refunds.Value.(json.RawMessage)) {
+		t.Errorf("expected one named example per operation but we got %+v, %+v", payments, refunds)
+	}
+}
+
+func TestPathParamsFromPattern(t *testing.T) {
+	doc := newTestDoc()
+
+	path := NewDefaultPathStructure(doc, "get", "/users/{id:[0-9]{1,5}}/files/{path...}", testHandler, SecurityNone).
+		ParamPath("id", DataTypeInteger, WithParamDescription("user id"))
+
+	if _, pattern, _ := path.MethodFunc(); pattern != "/users/{id:[0-9]{1,5}}/files/{path...}" {
+		t.Errorf("expected router pattern unchanged but we got %s", pattern)
+	}
+
+	p, ok := doc.Paths["/users/{id}/files/{path}"]["get"]
+	if !ok {
+		t.Fatalf("expected normalized path but we got %v", doc.Paths)
+	}
+
+	if len(p.Parameters) != 2 {
+		t.Fatalf("expected 2 path parameters but we got %d", len(p.Parameters))
+	}
+
+	id := p.Parameters[0]
+	if !id.Required || id.ParamSchema.Type != DataTypeInteger || id.ParamSchema.Pattern != "" || id.Description != "user id" {
+		t.Errorf("expected integer id refined by ParamPath without pattern but we got %+v %+v", id, id.ParamSchema)
+	}
+
+	if rest := p.Parameters[1]; rest.Name != "path" || !rest.Required || rest.ParamSchema.Type != DataTypeString {
+		t.Errorf("expected required string path parameter but we got %+v", rest)
+	}
+
+	NewDefaultPathStructure(doc, "get", "/codes/{code:[A-Z]{3}}", testHandler, SecurityNone).
+		ParamPath("code", DataTypeString, WithParamDescription("ISO code"))
+
+	if code := doc.Paths["/codes/{code}"]["get"].Parameters[0]; code.ParamSchema.Pattern != "^[A-Z]{3}$" {
+		t.Errorf("expected string code refined by ParamPath with pattern but we got %+v", code.ParamSchema)
+	}
+
+	NewDefaultPathStructure(doc, "get", "/orders/{$}", testHandler, SecurityNone)
+	if _, ok := doc.Paths["/orders/"]; !ok {
+		t.Errorf("expected {$} removed from path but we got %v", doc.Paths)
+	}
+}
+
+func TestOperationID(t *testing.T) {
+	doc := newTestDoc()
+
+	first := NewDefaultPathStructure(doc, "get", "/users", testHandler, SecurityNone).(*PathsStructure)
+	second := NewDefaultPathStructure(doc, "post", "/users", testHandler, SecurityNone).(*PathsStructure)
+	closure := NewDefaultPathStructure(doc, "get", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {}, SecurityNone).(*PathsStructure)
+
+	if first.OpId != "testHandler" || second.OpId != "postUsers" {
+		t.Errorf("expected operationId from handler name, then method and path but we got %s, %s", first.OpId, second.OpId)
+	}
+
+	if closure.OpId != "getUsersId" {
+		t.Errorf("expected operationId from method and path but we got %s", closure.OpId)
+	}
+
+	second.OperationID("createUser")
+	closure.OperationID("createUser")
+
+	if second.OpId != "createUser" || closure.OpId != "createUser2" {
+		t.Errorf("expected operationId override kept unique but we got %s, %s", second.OpId, closure.OpId)
+	}
+
+	if err := doc.Validate(); err == nil || !strings.Contains(err.Error(), `duplicate operationId "createUser"`) {
+		t.Errorf("expected duplicate operationId error but we got %v", err)
+	}
+
+	// Renomeado para um operationId único, a duplicidade deixa de existir.
+	closure.OperationID("getUser")
+
+	if err := doc.Validate(); err != nil {
+		t.Errorf("expected no error after renaming the operationId but we got %v", err)
+	}
+}
+
+type testUserController struct{}
+
+func (testUserController) Get(w http.ResponseWriter, r *http.Request) {}
+
+type testOrderController struct{}
+
+func (*testOrderController) Get(w http.ResponseWriter, r *http.Request) {}
+
+func TestOperationIDMethodValue(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		doc := newTestDoc()
+
+		register := []func(){
+			func() { NewDefaultPathStructure(doc, "get", "/users", testUserController{}.Get, SecurityNone) },
+			func() { NewDefaultPathStructure(doc, "get", "/orders", (&testOrderController{}).Get, SecurityNone) },
+		}
+		if reverse {
+			register[0], register[1] = register[1], register[0]
+		}
+		for _, fn := range register {
+			fn()
+		}
+
+		if users, orders := doc.Paths["/users"]["get"].OpId, doc.Paths["/orders"]["get"].OpId; users != "testUserController_Get" || orders != "testOrderController_Get" {
+			t.Errorf("expected operationId with receiver type but we got %s, %s", users, orders)
+		}
+	}
+}
+
+func TestDeprecatedHeadersDisabled(t *testing.T) {
+	doc := newTestDoc()
+
+	_, handler := NewDefaultPathStructure(doc, "get", "/users", testHandler, SecurityNone).Deprecated().HandleFunc()
+
+	if reflect.ValueOf(handler).Pointer() != reflect.ValueOf(testHandler).Pointer() {
+		t.Error("expected controller unchanged without DeprecationHeaders")
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	doc := newTestDoc()
+	doc.deprecationHeaders.Store(true)
+
+	sunset := time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	_, handler := NewDefaultPathStructure(doc, "get", "/users", testHandler, SecurityNone).
+		ParamQuery("name", DataTypeString, WithParamDeprecated()).
+		Sunset(sunset).
+		HandleFunc()
+
+	p := doc.Paths["/users"]["get"]
+	if !p.Deprec || p.XSunset != "2027-01-31" || !p.Parameters[0].Deprecated {
+		t.Errorf("expected deprecated operation with x-sunset and parameter but we got %+v", p)
+	}
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	if w.Header().Get("Deprecation") != "true" || w.Header().Get("Sunset") != "Sun, 31 Jan 2027 00:00:00 GMT" {
+		t.Errorf("expected Deprecation and Sunset headers but we got %v", w.Header())
+	}
+
+	orders := NewDefaultPathStructure(doc, "get", "/orders", testHandler, SecurityNone)
+	_, handler = orders.HandleFunc()
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Header().Get("Deprecation") != "" {
+		t.Errorf("expected no Deprecation header but we got %v", w.Header())
+	}
+
+	// Deprecated após o HandleFunc, o controller já registrado no router passa a informar os headers.
+	orders.Deprecated()
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Header().Get("Deprecation") != "true" {
+		t.Errorf("expected Deprecation header after HandleFunc but we got %v", w.Header())
+	}
+
+	orders.DeprecatedSince(time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC))
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Header().Get("Deprecation") != "@1782864000" || w.Header().Get("Sunset") != "" {
+		t.Errorf("expected RFC 9745 Deprecation header but we got %v", w.Header())
+	}
+}
+
+func TestTags(t *testing.T) {
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "get", "/users", testHandler, SecurityNone).
+		AddTags("users").
+		AddTags("admin", "users")
+
+	if tags := doc.Paths["/users"]["get"].Tags; !reflect.DeepEqual(tags, []string{"users", "admin"}) {
+		t.Errorf("expected tags [users admin] but we got %v", tags)
+	}
+
+	doc.AddTag(&TagDefinition{Name: "users", Description: "Users", group: "Registry"})
+	doc.AddTag(&TagDefinition{Name: "admin", group: "Security"})
+	doc.AddTag(&TagDefinition{Name: "users", Description: "Users and profiles", group: "Registry"})
+
+	b, err := json.Marshal(struct {
+		Tags      []*TagDefinition `json:"tags"`
+		TagGroups []TagGroup       `json:"x-tagGroups"`
+	}{doc.Tags, doc.TagGroups})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"tags":[{"name":"users","description":"Users and profiles"},{"name":"admin"}],"x-tagGroups":[{"name":"Registry","tags":["users"]},{"name":"Security","tags":["admin"]}]}`
+	if string(b) != expected {
+		t.Errorf("expected %s but we got %s", expected, b)
+	}
+}
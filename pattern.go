@@ -0,0 +1,62 @@
+package docapi
+
+import "strings"
+
+// pathParam parâmetro extraído do pattern da rota.
+type pathParam struct {
+	name string
+	// regex expressão regular do chi ({id:[0-9]+}).
+	regex string
+}
+
+// parsePattern extrai os parâmetros do pattern da rota e retorna o path documentado no formato do OpenAPI.
+//
+// net/http: /files/{path...} → /files/{path}; /users/{$} → /users/
+// chi: /users/{id:[0-9]+} → /users/{id}
+func parsePattern(pattern string) (path string, params []pathParam) {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '{' {
+			b.WriteByte(pattern[i])
+			continue
+		}
+
+		// A expressão regular do chi pode conter chaves, ex.: {id:[0-9]{3}}
+		end, depth := -1, 0
+		for j := i; j < len(pattern) && end < 0; j++ {
+			switch pattern[j] {
+			case '{':
+				depth++
+			case '}':
+				if depth--; depth == 0 {
+					end = j
+				}
+			}
+		}
+
+		if end < 0 {
+			b.WriteString(pattern[i:])
+			break
+		}
+
+		name, regex, _ := strings.Cut(pattern[i+1:end], ":")
+		name = strings.TrimSpace(strings.TrimSuffix(name, "..."))
+		i = end
+
+		// {$} indica somente o final do path no net/http.
+		if name == "$" || name == "" {
+			continue
+		}
+
+		if regex != "" {
+			// O chi compara a expressão com o segmento inteiro.
+			regex = "^" + strings.TrimSuffix(strings.TrimPrefix(regex, "^"), "$") + "$"
+		}
+
+		params = append(params, pathParam{name: name, regex: regex})
+		b.WriteString("{" + name + "}")
+	}
+
+	return b.String(), params
+}
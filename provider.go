@@ -0,0 +1,76 @@
+package docapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+// SchemaProvider permite que o tipo descreva o próprio schema OpenAPI, ao invés de ser documentado
+// conforme os campos da struct. Usado por tipos com serialização customizada (MarshalJSON),
+// ex.: valores monetários, decimais, wrappers de nulo e enums serializados como string.
+type SchemaProvider interface {
+	DocApiSchema() *Schema
+}
+
+// ExampleProvider permite que o tipo informe o próprio valor de exemplo.
+type ExampleProvider interface {
+	DocApiExample() any
+}
+
+var (
+	stringType        = reflect.TypeOf("")
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// providedSchema retorna uma cópia do schema informado pelo tipo, quando implementa SchemaProvider.
+// Os métodos podem ser implementados com receiver valor ou ponteiro.
+func providedSchema(t reflect.Type) (schema *Schema, ok bool) {
+	provider, ok := reflect.New(t).Interface().(SchemaProvider)
+	if !ok {
+		return
+	}
+
+	s := provider.DocApiSchema()
+	if s == nil {
+		return nil, false
+	}
+
+	schemaCopy := *s
+	return &schemaCopy, true
+}
+
+// providedExample retorna o exemplo informado pelo tipo, quando implementa ExampleProvider.
+func providedExample(t reflect.Type) (example any, ok bool) {
+	provider, ok := reflect.New(t).Interface().(ExampleProvider)
+	if !ok {
+		return
+	}
+	return provider.DocApiExample(), true
+}
+
+// isMarshaler indica os tipos com serialização customizada (encoding.TextMarshaler e json.Marshaler),
+// que são documentados como string quando não implementam SchemaProvider.
+func isMarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(textMarshalerType) || pt.Implements(jsonMarshalerType)
+}
+
+// defaultExample retorna o valor de exemplo padrão do DataType.
+func defaultExample(dt DataType) any {
+	switch dt {
+	case DataTypeInteger:
+		return 0
+	case DataTypeNumber:
+		return 0.1
+	case DataTypeBoolean:
+		return false
+	case DataTypeArray:
+		return []any{}
+	case DataTypeObject:
+		return map[string]any{}
+	default:
+		return "string"
+	}
+}
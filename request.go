@@ -7,6 +7,7 @@ type ResquestBody struct {
 	Content        ContentType `json:"content,omitempty"`
 	exempleSummary string      `json:"-"`
 	typeName       string      `json:"-"`
+	namedExamples  []OptsExample
 }
 
 func NewRequest(description string) *ResquestBody {
@@ -45,3 +46,10 @@ func WithReqTypeName(typeName string) OptsRequest {
 		r.typeName = typeName
 	}
 }
+
+// WithReqNamedExample adiciona um exemplo nomeado ao corpo da requisição, ver WithNamedExample.
+func WithReqNamedExample(name string, value any, opts ...OptsExample) OptsRequest {
+	return func(r *ResquestBody) {
+		r.namedExamples = append(r.namedExamples, WithNamedExample(name, value, opts...))
+	}
+}
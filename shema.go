@@ -1,42 +1,75 @@
 package docapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
+// https://swagger.io/docs/specification/data-models/
 type Schema struct {
-	OneOf    []Ref    `json:"oneOf,omitempty"`
-	Required []string `json:"required,omitempty"`
-	Type     DataType `json:"type,omitempty"`
+	Ref   string    `json:"$ref,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+	// Discriminator usado com oneOf/anyOf, ver Union.
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+	Required      []string       `json:"required,omitempty"`
+	Type          DataType       `json:"type,omitempty"`
+	Format        string         `json:"format,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Default       any            `json:"default,omitempty"`
+	Nullable      bool           `json:"nullable,omitempty"`
+	Deprecated    bool           `json:"deprecated,omitempty"`
+	ReadOnly      bool           `json:"readOnly,omitempty"`
+	WriteOnly     bool           `json:"writeOnly,omitempty"`
+	// Validações de string
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	// Validações de número
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	Enum             []any    `json:"enum,omitempty"`
 	// Preencher neste nível quando é object
 	Properties any    `json:"properties,omitempty"`
 	Items      *Items `json:"items,omitempty"`
+	// Validações de array
+	MinItems    *int `json:"minItems,omitempty"`
+	MaxItems    *int `json:"maxItems,omitempty"`
+	UniqueItems bool `json:"uniqueItems,omitempty"`
+	// Validações de map (quantidade de chaves)
+	MinProperties *int `json:"minProperties,omitempty"`
+	MaxProperties *int `json:"maxProperties,omitempty"`
+	// Preencher neste nível quando é map, descreve o tipo dos valores.
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// typeNull indica que o valor nulo é descrito com type: [tipo, "null"] (OpenAPI 3.1).
+	typeNull bool
+	// openAPI31 indica que exclusiveMinimum/exclusiveMaximum são descritos com o valor do limite (OpenAPI 3.1).
+	openAPI31 bool
 }
 
-type Items struct {
-	OneOf    []Ref    `json:"oneOf,omitempty"`
-	Required []string `json:"required,omitempty"`
-	Type     DataType `json:"type,omitempty"`
-	// Preencher neste nível quando é array
-	Properties any `json:"properties,omitempty"`
-}
+// Ref mantido por compatibilidade, as referências de oneOf/anyOf/allOf são Schema com o $ref preenchido.
+//
+// Deprecated: usar Schema{Ref: "#/components/schemas/Model"}.
+type Ref = Schema
 
-type Ref struct {
-	Ref string `json:"$ref"`
-}
+// Property representa o schema de um campo do modelo (dto).
+type Property = Schema
 
-type Property struct {
-	Format   string   `json:"format,omitempty"`
-	Type     DataType `json:"type,omitempty"`
-	Items    *Items   `json:"items,omitempty"`
-	Enum     []any    `json:"enum,omitempty"`
-	Required []string `json:"required,omitempty"`
-	Value    any      `json:"properties,omitempty"`
+// Items representa o schema dos itens de um array.
+type Items = Schema
+
+// schemaRef retorna a referência do schema registrado em components/schemas.
+func schemaRef(modelName string) string {
+	return "#/components/schemas/" + modelName
 }
 
 func (s *Schema) AddOneOfRef(modelName string, dataType DataType) {
-	ref := Ref{"#/components/schemas/" + modelName}
+	ref := &Schema{Ref: schemaRef(modelName)}
 
 	switch dataType {
 	case DataTypeArray:
@@ -50,30 +83,94 @@ func (s *Schema) AddOneOfRef(modelName string, dataType DataType) {
 	}
 }
 
+// MarshalJSON no OpenAPI 3.1 (JSON Schema 2020-12) o valor nulo é descrito com type: [tipo, "null"], ao invés de nullable,
+// e exclusiveMinimum/exclusiveMaximum recebem o valor do limite, ao invés de minimum/maximum com o booleano.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+
+	exclusive := s.openAPI31 && (s.ExclusiveMinimum || s.ExclusiveMaximum)
+	typeNull := s.typeNull && s.Type != schemaNone
+
+	if !exclusive && !typeNull {
+		return json.Marshal(schema(s))
+	}
+
+	out := struct {
+		schema
+		Type             any      `json:"type,omitempty"`
+		Nullable         bool     `json:"nullable,omitempty"`
+		Minimum          *float64 `json:"minimum,omitempty"`
+		Maximum          *float64 `json:"maximum,omitempty"`
+		ExclusiveMinimum any      `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum any      `json:"exclusiveMaximum,omitempty"`
+	}{
+		schema:   schema(s),
+		Nullable: s.Nullable,
+		Minimum:  s.Minimum,
+		Maximum:  s.Maximum,
+	}
+
+	if s.Type != schemaNone {
+		out.Type = s.Type
+	}
+
+	if typeNull {
+		out.Type = []DataType{s.Type, DataTypeNull}
+		out.Nullable = false
+	}
+
+	switch {
+	case !s.openAPI31:
+		out.ExclusiveMinimum, out.ExclusiveMaximum = omitFalse(s.ExclusiveMinimum), omitFalse(s.ExclusiveMaximum)
+	default:
+		if s.ExclusiveMinimum && s.Minimum != nil {
+			out.ExclusiveMinimum, out.Minimum = *s.Minimum, nil
+		}
+		if s.ExclusiveMaximum && s.Maximum != nil {
+			out.ExclusiveMaximum, out.Maximum = *s.Maximum, nil
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// omitFalse retorna nil para o false, mantendo o omitempty do campo.
+func omitFalse(b bool) any {
+	if !b {
+		return nil
+	}
+	return true
+}
+
 // ConvertEnumType responsável por converter o valor do enum conforme dt.
 func (p *Property) ConvertEnumType(dt DataType) {
 	for i, e := range p.Enum {
-		var (
-			value any
-			err   error
-			s     = fmt.Sprint(e)
-		)
-
-		switch dt {
-		case DataTypeInteger:
-			value, err = strconv.Atoi(s)
-		case DataTypeNumber:
-			value, err = strconv.ParseFloat(s, 64)
-		case DataTypeBoolean:
-			value, err = strconv.ParseBool(s)
-		default:
-			value = e
-		}
+		p.Enum[i] = convertType(dt, e)
+	}
+}
 
-		if err != nil {
-			value = e
-		}
+// convertType responsável por converter o valor conforme dt, caso não seja possível, retorna o próprio valor.
+func convertType(dt DataType, e any) any {
+	var (
+		value any
+		err   error
+		s     = fmt.Sprint(e)
+	)
 
-		p.Enum[i] = value
+	switch dt {
+	case DataTypeInteger:
+		value, err = strconv.Atoi(s)
+	case DataTypeNumber:
+		value, err = strconv.ParseFloat(s, 64)
+	case DataTypeBoolean:
+		value, err = strconv.ParseBool(s)
+	default:
+		value = e
 	}
+
+	if err != nil {
+		value = e
+	}
+
+	return value
 }
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 )
 
@@ -46,6 +47,8 @@ func NewDocApi(docURL string) *StartDocApi {
 }
 
 func (s *StartDocApi) Info(title, description, version string) *StartDocApi {
+	defer s.doc.lock()()
+
 	s.doc.Info.Title = title
 	s.doc.Info.Description = description
 	s.doc.Info.Version = version
@@ -53,6 +56,8 @@ func (s *StartDocApi) Info(title, description, version string) *StartDocApi {
 }
 
 func (s *StartDocApi) Contact(name string, opts ...OptsContact) *StartDocApi {
+	defer s.doc.lock()()
+
 	c := &Contact{Name: name}
 	for _, fn := range opts {
 		fn(c)
@@ -62,6 +67,8 @@ func (s *StartDocApi) Contact(name string, opts ...OptsContact) *StartDocApi {
 }
 
 func (s *StartDocApi) License(name, url string) *StartDocApi {
+	defer s.doc.lock()()
+
 	s.doc.Info.License = &License{
 		Name: name, Url: url,
 	}
@@ -69,6 +76,8 @@ func (s *StartDocApi) License(name, url string) *StartDocApi {
 }
 
 func (s *StartDocApi) ExternalDocs(description, helpURL string) *StartDocApi {
+	defer s.doc.lock()()
+
 	s.doc.ExternalDocs = &ExternalDocs{
 		Description: description,
 		URL:         helpURL,
@@ -81,6 +90,112 @@ func (s *StartDocApi) Server(url ...string) *StartDocApi {
 	return s
 }
 
+// TagDefinition adiciona a descrição da tag na lista de tags do doc.json, a ordem de definição é a ordem exibida.
+// Ex.: TagDefinition("users", "Cadastro de usuários", docapi.WithTagExternalDocs("Manual", "https://..."), docapi.WithTagGroup("Cadastros"))
+func (s *StartDocApi) TagDefinition(name, description string, opts ...OptsTag) *StartDocApi {
+	tag := &TagDefinition{Name: name, Description: description}
+	for _, fn := range opts {
+		fn(tag)
+	}
+
+	s.doc.AddTag(tag)
+	return s
+}
+
+// ExampleDepth define a quantidade máxima de vezes que uma struct com auto relacionamento
+// é repetida no exemplo. O schema não é afetado, a recursão é descrita via $ref.
+func (s *StartDocApi) ExampleDepth(depth int) *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.ExampleDepth = depth
+	return s
+}
+
+// InferRequired define como obrigatórios os campos que sempre são serializados (sem omitempty e não ponteiro),
+// a tag docapi required prevalece.
+func (s *StartDocApi) InferRequired() *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.InferRequired = true
+	return s
+}
+
+// ValidateTag habilita a leitura da tag validate (github.com/go-playground/validator), convertendo as regras
+// em restrições do schema (required, minLength, maximum, enum, format...). A tag docapi prevalece.
+func (s *StartDocApi) ValidateTag() *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.ValidateTag = true
+	return s
+}
+
+// TypeOverride registra o schema e o exemplo fixos do tipo de model, usado para tipos de terceiros que são
+// serializados de forma diferente dos campos da struct. Ex.: TypeOverride(decimal.Decimal{}, &docapi.Schema{Type: docapi.DataTypeString}, "10.50")
+//
+// Já são registrados os tipos json.RawMessage e time.Duration.
+func (s *StartDocApi) TypeOverride(model any, schema *Schema, example any) *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.AddTypeOverride(reflect.TypeOf(model), schema, example)
+	return s
+}
+
+// OpenAPI31 gera o doc.json na versão 3.1 do OpenAPI, o valor nulo é descrito com type: [tipo, "null"].
+// Deve ser chamado antes de configurar os endpoints.
+func (s *StartDocApi) OpenAPI31() *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Version = "3.1.0"
+	s.doc.Components.TypeNull = true
+	return s
+}
+
+// DisableNullablePointer campos ponteiro não são documentados como nullable, usado quando o ponteiro
+// indica somente que o campo é opcional. Deve ser chamado antes de configurar os endpoints.
+func (s *StartDocApi) DisableNullablePointer() *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.DisableNullablePointer = true
+	return s
+}
+
+// SchemaNamer define a estratégia de nome dos schemas em components/schemas, ex.: docapi.PackageSchemaName.
+// Deve ser chamado antes de configurar os endpoints.
+func (s *StartDocApi) SchemaNamer(namer SchemaNamer) *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.Components.SchemaNamer = namer
+	return s
+}
+
+// Err retorna os erros encontrados ao gerar a documentação, ex.: SchemaCollisionError.
+func (s *StartDocApi) Err() error {
+	s.doc.mu.RLock()
+	defer s.doc.mu.RUnlock()
+
+	return s.doc.Components.Err()
+}
+
+// Validate verifica os parâmetros de path, endpoints duplicados e os schemas, ver Doc.Validate.
+func (s *StartDocApi) Validate() error {
+	return s.doc.Validate()
+}
+
+// ValidateOnHandle valida cada endpoint no HandleFunc/MethodFunc, registrando no log os problemas encontrados.
+func (s *StartDocApi) ValidateOnHandle() *StartDocApi {
+	defer s.doc.lock()()
+
+	s.doc.validateOnHandle = true
+	return s
+}
+
+// DeprecationHeaders adiciona os headers Deprecation (RFC 9745) e Sunset (RFC 8594) na resposta dos endpoints deprecated,
+// deve ser chamado antes do HandleFunc/MethodFunc, sem ele o controller é retornado sem alteração.
+func (s *StartDocApi) DeprecationHeaders() *StartDocApi {
+	s.doc.deprecationHeaders.Store(true)
+	return s
+}
+
 // NewRouter para iniciar a configuração de endpoint.
 func (s *StartDocApi) NewRouter() Router {
 	return newRouter(s.doc, SecurityNone)
@@ -91,7 +206,7 @@ func (s *StartDocApi) NewRouterSecurityBasic() Router {
 	ss := NewSecurityShemes(SecurityHttp)
 	ss.TypeName = SecurityBasic.String()
 	ss.Schema = SecurityBasic.String()
-	s.doc.Components.AddSecurity(ss)
+	s.doc.addSecurity(ss)
 	return newRouter(s.doc, SecurityBasic)
 }
 
@@ -101,7 +216,7 @@ func (s *StartDocApi) NewRouterSecurityBearer() Router {
 	ss.TypeName = SecurityBearer.String()
 	ss.Schema = SecurityBearer.String()
 	ss.Format = "JWT"
-	s.doc.Components.AddSecurity(ss)
+	s.doc.addSecurity(ss)
 	return newRouter(s.doc, SecurityBearer)
 }
 
@@ -122,7 +237,7 @@ func (s *StartDocApi) newRouterSecurityApiKey(key, in string) Router {
 	if key == "" {
 		ss.Name = "apiKey"
 	}
-	s.doc.Components.AddSecurity(ss)
+	s.doc.addSecurity(ss)
 	return newRouter(s.doc, SecurityApiKey)
 }
 
@@ -144,20 +259,20 @@ func (s *StartDocApi) newRouterSecurityOAuth2(tokenUrl, in string) Router {
 			Scopes:   &SecurityScope{},
 		},
 	}
-	s.doc.Components.AddSecurity(ss)
+	s.doc.addSecurity(ss)
 	return newRouter(s.doc, SecurityOAuth2)
 }
 
 // HandlerFn responsável por retornar o endereço do swagger e a função do controller.
-func (s *StartDocApi) HandlerFunc() (pattern string, controller http.HandlerFunc) {
+func (s *StartDocApi) HandlerFunc(opts ...OptsHandler) (pattern string, controller http.HandlerFunc) {
 	slog.Info("DocApi", "URL", s.url)
-	return s.path + "*", HandlerFunc(s.url + "doc.json")
+	return s.path + "*", HandlerFunc(s.url+"doc.json", opts...)
 }
 
 // HandlerFunc responsável por retornar o endereço do swagger e a função do controller.
 //
 // Uso no net/http
-func (s *StartDocApi) HandlerFuncNetHttp() (pattern string, controller http.HandlerFunc) {
+func (s *StartDocApi) HandlerFuncNetHttp(opts ...OptsHandler) (pattern string, controller http.HandlerFunc) {
 	slog.Info("DocApi", "URL", s.url)
-	return s.path, HandlerFunc(s.url + "doc.json")
+	return s.path, HandlerFunc(s.url+"doc.json", opts...)
 }
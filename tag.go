@@ -0,0 +1,225 @@
+package docapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagDocApi dados extraídos da tag docapi, no formato chave:valor separados por ponto e vírgula.
+//
+// Ex.: `docapi:"example:john;required:true;minLength:3;maxLength:50"`
+type tagDocApi struct {
+	example     string
+	enum        []any
+	defaultTag  string
+	format      string
+	description string
+	pattern     string
+	// required é nil quando não foi informado na tag.
+	required         *bool
+	minLength        *int
+	maxLength        *int
+	minItems         *int
+	maxItems         *int
+	minProperties    *int
+	maxProperties    *int
+	minimum          *float64
+	maximum          *float64
+	multipleOf       *float64
+	exclusiveMinimum bool
+	exclusiveMaximum bool
+	uniqueItems      bool
+	deprecated       bool
+	readOnly         bool
+	writeOnly        bool
+	nullable         bool
+}
+
+// parseTags responsável por extrair os dados das tags do campo.
+// Quando ValidateTag está habilitado, a tag validate é usada como base e a tag docapi prevalece.
+func (c *Components) parseTags(f field) (tag tagDocApi) {
+	if c.ValidateTag {
+		tag = c.parseTagValidate(f.structField.Tag.Get("validate"), f.typ)
+	}
+
+	return c.parseTagDocApi(f.structField.Tag.Get("docapi"), tag)
+}
+
+// parseTagDocApi responsável por extrair os dados da tag docapi, sobrescrevendo os dados de tag.
+// Valores inválidos são ignorados.
+func (c *Components) parseTagDocApi(tagdocapi string, tag tagDocApi) tagDocApi {
+	for _, v := range strings.Split(tagdocapi, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(v), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "required":
+			isReq := value == "true"
+			tag.required = &isReq
+		case "example":
+			tag.example = value
+		case "enum":
+			tag.enum = nil
+			for _, e := range strings.Split(value, ",") {
+				tag.enum = append(tag.enum, strings.TrimSpace(e))
+			}
+		case "default":
+			tag.defaultTag = value
+		case "format":
+			tag.format = value
+		case "description":
+			tag.description = value
+		case "pattern":
+			tag.pattern = value
+		case "minLength":
+			tag.minLength = parseTagInt(value)
+		case "maxLength":
+			tag.maxLength = parseTagInt(value)
+		case "minItems":
+			tag.minItems = parseTagInt(value)
+		case "maxItems":
+			tag.maxItems = parseTagInt(value)
+		case "minProperties":
+			tag.minProperties = parseTagInt(value)
+		case "maxProperties":
+			tag.maxProperties = parseTagInt(value)
+		case "minimum":
+			tag.minimum = parseTagFloat(value)
+		case "maximum":
+			tag.maximum = parseTagFloat(value)
+		case "multipleOf":
+			tag.multipleOf = parseTagFloat(value)
+		case "exclusiveMinimum":
+			// Aceita true/false (OpenAPI 3.0) ou o valor do limite.
+			if tag.exclusiveMinimum = value == "true"; !tag.exclusiveMinimum {
+				if tag.minimum = parseTagFloat(value); tag.minimum != nil {
+					tag.exclusiveMinimum = true
+				}
+			}
+		case "exclusiveMaximum":
+			if tag.exclusiveMaximum = value == "true"; !tag.exclusiveMaximum {
+				if tag.maximum = parseTagFloat(value); tag.maximum != nil {
+					tag.exclusiveMaximum = true
+				}
+			}
+		case "uniqueItems":
+			tag.uniqueItems = value == "true"
+		case "deprecated":
+			tag.deprecated = value == "true"
+		case "readOnly":
+			tag.readOnly = value == "true"
+		case "writeOnly":
+			tag.writeOnly = value == "true"
+		case "nullable":
+			tag.nullable = value == "true"
+		}
+	}
+	return tag
+}
+
+// isAllOf indica a struct embutida que deve ser documentada com allOf: [$ref Base, {campos próprios}],
+// ao invés de ter os campos promovidos. Ex.: `docapi:"allOf:true"`
+func isAllOf(sf reflect.StructField) bool {
+	for _, v := range strings.Split(sf.Tag.Get("docapi"), ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(v), ":")
+		if strings.TrimSpace(key) == "allOf" {
+			return strings.TrimSpace(value) == "true"
+		}
+	}
+	return false
+}
+
+// applyValue aplica na property as restrições de valor (string e número), somente as informadas na tag.
+// Em slice/array e map são aplicadas nos itens.
+func (t tagDocApi) applyValue(p *Property) {
+	if t.format != "" {
+		p.Format = t.format
+	}
+
+	if t.defaultTag != "" {
+		p.Default = convertType(p.Type, t.defaultTag)
+	}
+
+	if t.pattern != "" {
+		p.Pattern = t.pattern
+	}
+
+	if t.minLength != nil {
+		p.MinLength = t.minLength
+	}
+
+	if t.maxLength != nil {
+		p.MaxLength = t.maxLength
+	}
+
+	if t.minimum != nil {
+		p.Minimum = t.minimum
+	}
+
+	if t.maximum != nil {
+		p.Maximum = t.maximum
+	}
+
+	if t.multipleOf != nil {
+		p.MultipleOf = t.multipleOf
+	}
+
+	p.ExclusiveMinimum = p.ExclusiveMinimum || t.exclusiveMinimum
+	p.ExclusiveMaximum = p.ExclusiveMaximum || t.exclusiveMaximum
+}
+
+// applyField aplica na property os atributos do campo, somente os informados na tag.
+func (t tagDocApi) applyField(p *Property) {
+	if t.description != "" {
+		p.Description = t.description
+	}
+
+	p.Deprecated = p.Deprecated || t.deprecated
+	p.ReadOnly = p.ReadOnly || t.readOnly
+	p.WriteOnly = p.WriteOnly || t.writeOnly
+	p.Nullable = p.Nullable || t.nullable
+
+	if p.Type == DataTypeObject {
+		if t.minProperties != nil {
+			p.MinProperties = t.minProperties
+		}
+
+		if t.maxProperties != nil {
+			p.MaxProperties = t.maxProperties
+		}
+	}
+
+	if p.Type != DataTypeArray {
+		return
+	}
+
+	if t.minItems != nil {
+		p.MinItems = t.minItems
+	}
+
+	if t.maxItems != nil {
+		p.MaxItems = t.maxItems
+	}
+
+	p.UniqueItems = p.UniqueItems || t.uniqueItems
+}
+
+func parseTagInt(value string) *int {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func parseTagFloat(value string) *float64 {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,74 @@
+package docapi
+
+// https://swagger.io/docs/specification/grouping-operations-with-tags/
+type TagDefinition struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+	// group nome do grupo da tag no x-tagGroups, ver WithTagGroup.
+	group string
+}
+
+// TagGroup agrupamento de tags do Redoc (x-tagGroups).
+type TagGroup struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type OptsTag func(*TagDefinition)
+
+func WithTagExternalDocs(description, url string) OptsTag {
+	return func(t *TagDefinition) {
+		t.ExternalDocs = &ExternalDocs{Description: description, URL: url}
+	}
+}
+
+// WithTagGroup adiciona a tag no grupo do x-tagGroups (Redoc), os grupos mantêm a ordem de criação.
+func WithTagGroup(group string) OptsTag {
+	return func(t *TagDefinition) {
+		t.group = group
+	}
+}
+
+// AddTag adiciona a definição da tag na lista de tags do doc.json, mantendo a ordem de criação.
+// Tag já definida é substituída na mesma posição.
+func (j *Doc) AddTag(tag *TagDefinition) {
+	defer j.lock()()
+
+	replaced := false
+	for i, v := range j.Tags {
+		if v.Name == tag.Name {
+			j.Tags[i] = tag
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		j.Tags = append(j.Tags, tag)
+	}
+
+	j.TagGroups = tagGroups(j.Tags)
+}
+
+// tagGroups gera o x-tagGroups conforme o grupo de cada tag.
+func tagGroups(tags []*TagDefinition) (groups []TagGroup) {
+	index := make(map[string]int, len(tags))
+
+	for _, tag := range tags {
+		if tag.group == "" {
+			continue
+		}
+
+		i, ok := index[tag.group]
+		if !ok {
+			i = len(groups)
+			index[tag.group] = i
+			groups = append(groups, TagGroup{Name: tag.group})
+		}
+
+		groups[i].Tags = append(groups[i].Tags, tag.Name)
+	}
+
+	return
+}
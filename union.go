@@ -0,0 +1,113 @@
+package docapi
+
+import "reflect"
+
+// UnionType indica como os modelos do corpo polimórfico são combinados.
+type UnionType string
+
+const (
+	UnionOneOf UnionType = "oneOf"
+	UnionAnyOf UnionType = "anyOf"
+)
+
+// Union corpo polimórfico, usado no lugar do modelo em RequestBodyJson e ResponseBodyJson
+// quando o corpo pode ser um entre vários modelos.
+//
+// https://swagger.io/docs/specification/data-models/inheritance-and-polymorphism/
+type Union struct {
+	unionType    UnionType
+	models       []any
+	propertyName string
+	mapping      []unionMapping
+}
+
+type unionMapping struct {
+	value string
+	model any
+}
+
+// OneOf o corpo é exatamente um dos modelos. Ex.: OneOf(CardPayment{}, PixPayment{})
+func OneOf(models ...any) *Union {
+	return &Union{unionType: UnionOneOf, models: models}
+}
+
+// AnyOf o corpo é um ou mais dos modelos.
+func AnyOf(models ...any) *Union {
+	return &Union{unionType: UnionAnyOf, models: models}
+}
+
+// Discriminator define a propriedade, presente em todos os modelos, que identifica o modelo do corpo.
+func (u *Union) Discriminator(propertyName string) *Union {
+	u.propertyName = propertyName
+	return u
+}
+
+// Mapping associa o valor da propriedade do Discriminator ao modelo.
+// Quando não informado, o valor é o nome do schema do modelo.
+func (u *Union) Mapping(value string, model any) *Union {
+	u.mapping = append(u.mapping, unionMapping{value: value, model: model})
+	return u
+}
+
+// Discriminator https://swagger.io/docs/specification/data-models/inheritance-and-polymorphism/
+type Discriminator struct {
+	PropertyName string `json:"propertyName"`
+	// A chave é o valor da propriedade e o valor a referência do schema.
+	Mapping map[string]string `json:"mapping,omitempty"`
+}
+
+// parseUnion responsável por registrar o schema e o exemplo de cada modelo do corpo polimórfico,
+// gerando o oneOf/anyOf com as referências e o discriminator.
+func (p *PathsStructure) parseUnion(content *Content, union *Union, opts ...OptsExample) PathStructure {
+	var (
+		refs  []*Schema
+		names = make(map[reflect.Type]string, len(union.models))
+	)
+
+	for _, model := range union.models {
+		modelValue, modelType, dataType, ok := modelOf(model)
+		if !ok || dataType != DataTypeObject {
+			continue
+		}
+
+		modelName := p.Doc.Components.AddSchemasAndExamples(modelValue, modelType, dataType, opts...)
+		names[modelType] = modelName
+
+		refs = append(refs, &Schema{Ref: schemaRef(modelName)})
+		addBodyExample(content, modelName, model, opts...)
+	}
+
+	schema := &Schema{}
+	switch union.unionType {
+	case UnionAnyOf:
+		schema.AnyOf = refs
+	default:
+		schema.OneOf = refs
+	}
+
+	if union.propertyName != "" {
+		schema.Discriminator = &Discriminator{PropertyName: union.propertyName}
+
+		for _, m := range union.mapping {
+			modelName, ok := names[indirectType(reflect.TypeOf(m.model))]
+			if !ok {
+				continue
+			}
+
+			if schema.Discriminator.Mapping == nil {
+				schema.Discriminator.Mapping = make(map[string]string, len(union.mapping))
+			}
+			schema.Discriminator.Mapping[m.value] = schemaRef(modelName)
+		}
+	}
+
+	// O corpo polimórfico não tem um modelo, os exemplos nomeados são prefixados pela operação.
+	prefix := p.OpId
+	if prefix == "" {
+		prefix = defaultOperationID("", p.Method, p.Path)
+	}
+	p.Doc.Components.addNamedExamples(content, prefix, opts...)
+
+	content.Schemas = schema
+	return p
+}
@@ -0,0 +1,195 @@
+package docapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// validateFormats regras da tag validate que equivalem a um formato OpenAPI.
+var validateFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+}
+
+// validatePatterns regras da tag validate que equivalem a um pattern.
+var validatePatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+}
+
+// parseTagValidate responsável por converter as regras da tag validate (github.com/go-playground/validator)
+// nas restrições da tag docapi. Regras não suportadas são ignoradas.
+//
+// min, max e len são convertidas conforme o tipo: tamanho para string, quantidade de itens para slice/array/map
+// e valor para número. As regras após dive são aplicadas nos itens.
+//
+// Ex.: `validate:"required,min=1,dive,email"`
+func (c *Components) parseTagValidate(validate string, fieldType reflect.Type) (tag tagDocApi) {
+	if validate == "" || validate == "-" {
+		return
+	}
+
+	var (
+		t     = indirectType(fieldType)
+		items bool
+		keys  bool
+	)
+
+	for _, rule := range strings.Split(validate, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		// Regras das chaves do map não são documentadas.
+		switch {
+		case name == "keys":
+			keys = true
+			continue
+		case name == "endkeys":
+			keys = false
+			continue
+		case keys:
+			continue
+		}
+
+		switch name {
+		case "dive":
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				t = indirectType(t.Elem())
+			}
+			items = true
+
+		case "required":
+			if !items {
+				required := true
+				tag.required = &required
+			}
+
+		case "min", "gte":
+			tag.setMin(t, param, false)
+
+		case "gt":
+			tag.setMin(t, param, true)
+
+		case "max", "lte":
+			tag.setMax(t, param, false)
+
+		case "lt":
+			tag.setMax(t, param, true)
+
+		case "len":
+			tag.setMin(t, param, false)
+			tag.setMax(t, param, false)
+
+		case "oneof":
+			tag.enum = nil
+			for _, e := range strings.Fields(param) {
+				tag.enum = append(tag.enum, strings.Trim(e, "'"))
+			}
+
+		case "unique":
+			tag.uniqueItems = true
+
+		default:
+			if format, ok := validateFormats[name]; ok {
+				tag.format = format
+			}
+
+			if pattern, ok := validatePatterns[name]; ok {
+				tag.pattern = pattern
+			}
+		}
+	}
+
+	return
+}
+
+// setMin aplica o limite mínimo conforme o tipo t.
+func (t *tagDocApi) setMin(fieldType reflect.Type, param string, exclusive bool) {
+	switch fieldType.Kind() {
+	case reflect.String:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v++
+			}
+			t.minLength = v
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v++
+			}
+			t.minItems = v
+		}
+
+	case reflect.Map:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v++
+			}
+			t.minProperties = v
+		}
+
+	default:
+		if isNumber(fieldType) {
+			t.minimum = parseTagFloat(param)
+			t.exclusiveMinimum = exclusive && t.minimum != nil
+		}
+	}
+}
+
+// setMax aplica o limite máximo conforme o tipo t.
+func (t *tagDocApi) setMax(fieldType reflect.Type, param string, exclusive bool) {
+	switch fieldType.Kind() {
+	case reflect.String:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v--
+			}
+			t.maxLength = v
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v--
+			}
+			t.maxItems = v
+		}
+
+	case reflect.Map:
+		if v := parseTagInt(param); v != nil {
+			if exclusive {
+				*v--
+			}
+			t.maxProperties = v
+		}
+
+	default:
+		if isNumber(fieldType) {
+			t.maximum = parseTagFloat(param)
+			t.exclusiveMaximum = exclusive && t.maximum != nil
+		}
+	}
+}
+
+func isNumber(t reflect.Type) bool {
+	_, ok := kindFormats[t.Kind()]
+	return ok
+}
+
+// indirectType retorna o tipo apontado quando t é ponteiro.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,140 @@
+package docapi
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValidationError problema encontrado na validação da documentação de um endpoint.
+type ValidationError struct {
+	Method  string
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("docapi: %s %s: %s", strings.ToUpper(e.Method), e.Path, e.Message)
+}
+
+// Validate verifica a documentação gerada, reportando:
+//   - parâmetros de path que não existem no pattern, não foram declarados, estão duplicados ou não são obrigatórios;
+//   - endpoints registrados mais de uma vez com o mesmo método e path;
+//   - parâmetros com o mesmo nome e localização;
+//   - operationId informado no OperationID em mais de um endpoint;
+//   - erros dos schemas, ver Components.Err.
+func (j *Doc) Validate() error {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	errs := slices.Clone(j.errs)
+
+	for _, path := range slices.Sorted(maps.Keys(j.Paths)) {
+		for _, method := range slices.Sorted(maps.Keys(j.Paths[path])) {
+			errs = append(errs, j.Paths[path][method].validate()...)
+		}
+	}
+
+	errs = append(errs, j.validateOperationIDs()...)
+
+	if j.Components != nil {
+		errs = append(errs, j.Components.Err())
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOperationIDs reporta os endpoints que informaram o mesmo operationId, conforme os endpoints registrados
+// no momento da validação. Deve ser chamado com a documentação bloqueada.
+func (j *Doc) validateOperationIDs() (errs []error) {
+	endpoints := make(map[string][]*ValidationError)
+	for _, path := range slices.Sorted(maps.Keys(j.Paths)) {
+		for _, method := range slices.Sorted(maps.Keys(j.Paths[path])) {
+			if id := j.Paths[path][method].operationID; id != "" {
+				endpoints[id] = append(endpoints[id], &ValidationError{Method: method, Path: path, Message: "duplicate operationId " + strconv.Quote(id)})
+			}
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(endpoints)) {
+		if len(endpoints[id]) > 1 {
+			for _, err := range endpoints[id] {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return
+}
+
+// validate verifica os parâmetros do endpoint, ver Doc.Validate.
+func (p *PathsStructure) validate() (errs []error) {
+	path := p.Path
+	if path == "" {
+		path = p.Pattern
+	}
+
+	report := func(format string, args ...any) {
+		errs = append(errs, &ValidationError{Method: p.Method, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	_, placeholders := parsePattern(path)
+
+	type paramKey struct {
+		in   ParamIn
+		name string
+	}
+	declared := make(map[paramKey]bool, len(p.Parameters))
+
+	for _, param := range p.Parameters {
+		key := paramKey{in: param.In, name: param.Name}
+		if declared[key] {
+			report("duplicate %s parameter %q", param.In, param.Name)
+			continue
+		}
+		declared[key] = true
+
+		if param.In != ParamPath {
+			continue
+		}
+
+		if !slices.ContainsFunc(placeholders, func(pp pathParam) bool { return pp.name == param.Name }) {
+			report("path parameter %q not found in path", param.Name)
+		}
+
+		// O OpenAPI exige que parâmetros de path sejam obrigatórios.
+		if !param.Required {
+			report("path parameter %q must be required", param.Name)
+		}
+	}
+
+	for _, placeholder := range placeholders {
+		if !declared[paramKey{in: ParamPath, name: placeholder.name}] {
+			report("path parameter %q not declared", placeholder.name)
+		}
+	}
+
+	return
+}
+
+// validateOnHandle registra no log os problemas do endpoint quando habilitado, ver StartDocApi.ValidateOnHandle.
+func (p *PathsStructure) validateOnHandle() {
+	if p.Doc == nil {
+		return
+	}
+
+	p.Doc.mu.RLock()
+	defer p.Doc.mu.RUnlock()
+
+	if !p.Doc.validateOnHandle {
+		return
+	}
+
+	for _, err := range p.validate() {
+		slog.Error("[DocApi]", "error", err.Error())
+	}
+}
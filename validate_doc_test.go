@@ -0,0 +1,44 @@
+package docapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocValidate(t *testing.T) {
+	if err := newTestDoc().Validate(); err != nil {
+		t.Errorf("expected valid empty doc but we got %v", err)
+	}
+
+	doc := newTestDoc()
+
+	NewDefaultPathStructure(doc, "get", "/orders/{id}", testHandler, SecurityNone).
+		ParamPath("orderId", DataTypeInteger).
+		ParamQuery("name", DataTypeString).
+		ParamQuery("name", DataTypeString)
+
+	NewDefaultPathStructure(doc, "get", "/users/{id}", testHandler, SecurityNone)
+	NewDefaultPathStructure(doc, "get", "/users/{id}", testHandler, SecurityNone)
+
+	p := NewDefaultPathStructure(doc, "delete", "/users/{id}", testHandler, SecurityNone).(*PathsStructure)
+	p.Parameters = append(p.Parameters[1:], &Parameter{In: ParamPath, Name: "id", ParamSchema: &Schema{}})
+
+	err := doc.Validate()
+
+	var validation *ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected ValidationError but we got %v", err)
+	}
+
+	expected := []string{
+		`docapi: GET /users/{id}: duplicate registration`,
+		`docapi: GET /orders/{id}: path parameter "orderId" not found in path`,
+		`docapi: GET /orders/{id}: path parameter "orderId" must be required`,
+		`docapi: GET /orders/{id}: duplicate query parameter "name"`,
+		`docapi: DELETE /users/{id}: path parameter "id" must be required`,
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\nbut we got\n%v", strings.Join(expected, "\n"), err)
+	}
+}